@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errInvalidMapping = errors.New("mapping must be in the form URI=VALUE")
+
+// stringList is a repeatable flag; each occurrence may also hold a
+// comma-separated list of values.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+
+	return nil
+}
+
+// mappingList is a repeatable URI=VALUE flag.
+type mappingList []mapping
+
+type mapping struct {
+	uri   string
+	value string
+}
+
+func (l *mappingList) String() string {
+	s := make([]string, 0, len(*l))
+	for _, m := range *l {
+		s = append(s, m.uri+"="+m.value)
+	}
+
+	return strings.Join(s, ",")
+}
+
+func (l *mappingList) Set(value string) error {
+	// Values never contain "=", but URIs may (as part of a query string).
+	return l.add(value, strings.LastIndex(value, "="))
+}
+
+// add appends the mapping of value that is split at index i.
+func (l *mappingList) add(value string, i int) error {
+	if i <= 0 || i == len(value)-1 {
+		return fmt.Errorf("%w: %q", errInvalidMapping, value)
+	}
+
+	*l = append(*l, mapping{uri: value[:i], value: value[i+1:]})
+
+	return nil
+}
+
+// mirrorList is a repeatable PREFIX=DIR flag. Unlike other mappings, it is
+// split at the first "=": URL prefixes end before any query string, but
+// directories may contain one.
+type mirrorList mappingList
+
+func (l *mirrorList) String() string {
+	return (*mappingList)(l).String()
+}
+
+func (l *mirrorList) Set(value string) error {
+	return (*mappingList)(l).add(value, strings.Index(value, "="))
+}
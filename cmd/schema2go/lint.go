@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/walteh/schema2go/pkg/generator"
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+const (
+	lintFormatText = "text"
+	lintFormatJSON = "json"
+)
+
+var (
+	errLintFindings = errors.New("schemas have lint findings")
+	errLintFormat   = errors.New("unknown -lint-format")
+)
+
+// lint reports the findings of every input schema in the requested format,
+// and fails if there are any.
+func lint(opts *options, stdout io.Writer) error {
+	if opts.lintFormat != lintFormatText && opts.lintFormat != lintFormatJSON {
+		return fmt.Errorf("%w %q; use %s or %s", errLintFormat, opts.lintFormat, lintFormatText, lintFormatJSON)
+	}
+
+	if len(opts.inputs) == 0 {
+		return errNoInputs
+	}
+
+	loader := schemas.NewFileLoader(opts.resolveExtensions, opts.yamlExtensions)
+	findings := []generator.Finding{}
+
+	for _, input := range opts.inputs {
+		schema, err := loadLintInput(loader, input)
+		if err != nil {
+			return fmt.Errorf("failed to lint %s: %w", input, err)
+		}
+
+		findings = append(findings, generator.Lint(input, schema)...)
+	}
+
+	if opts.lintFormat == lintFormatJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(findings); err != nil {
+			return fmt.Errorf("failed to write findings: %w", err)
+		}
+	} else {
+		for _, f := range findings {
+			fmt.Fprintln(stdout, f)
+		}
+	}
+
+	if len(findings) > 0 {
+		return fmt.Errorf("%w: %d finding(s)", errLintFindings, len(findings))
+	}
+
+	return nil
+}
+
+func loadLintInput(loader schemas.Loader, input string) (*schemas.Schema, error) {
+	if input != "-" {
+		return loader.Load(input, "")
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("error reading standard input: %w", err)
+	}
+
+	schema, err := schemas.FromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	schema.SetSource(input, data)
+
+	return schema, nil
+}
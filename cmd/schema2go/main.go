@@ -0,0 +1,409 @@
+// Command schema2go generates Go types from JSON Schema documents.
+//
+// It is a thin wrapper around generator.Config and is meant to be used from
+// go:generate directives:
+//
+//	//go:generate go run github.com/walteh/schema2go/cmd/schema2go -p example -o types.go schema.json
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+
+	"github.com/walteh/schema2go/pkg/generator"
+	"github.com/walteh/schema2go/pkg/project"
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+const outputStdout = "-"
+
+var (
+	errNoInputs      = errors.New("no input schemas given")
+	errNoPackageName = errors.New("no package name given; use -p or -schema-package")
+	errStale         = errors.New("generated code is out of date")
+	errWatchCheck    = errors.New("-watch and -check cannot be combined")
+	errCheckRemove   = errors.New("-check and -remove-stale cannot be combined")
+	errConfigInputs  = errors.New("input schemas cannot be combined with -config; list them in the project file")
+	errLintMode      = errors.New("-lint cannot be combined with -config, -check, -remove-stale or -watch")
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "schema2go: %v\n", err)
+		}
+
+		os.Exit(1)
+	}
+}
+
+type options struct {
+	packageName         string
+	outputName          string
+	schemaPackages      mappingList
+	schemaOutputs       mappingList
+	schemaRootTypes     mappingList
+	tags                stringList
+	capitalizations     stringList
+	resolveExtensions   stringList
+	yamlExtensions      stringList
+	mirrors             mirrorList
+	allowDirs           stringList
+	allowSchemes        stringList
+	allowHosts          stringList
+	maxSchemaBytes      int64
+	maxRefDepth         int
+	minSizedInts        bool
+	onlyModels          bool
+	extraImports        bool
+	structNameFromTitle bool
+	skipValidation      bool
+	verbose             bool
+	configFile          string
+	check               bool
+	removeStale         bool
+	watch               bool
+	watchInterval       time.Duration
+	lint                bool
+	lintFormat          string
+	inputs              []string
+}
+
+func newFlagSet(opts *options, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("schema2go", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: schema2go [flags] FILE...\n       schema2go [flags] -config FILE\n\n"+
+			"Generates Go types from the given JSON Schema files. Use - to read a schema from standard input.\n\n")
+		fs.PrintDefaults()
+	}
+
+	fs.StringVar(&opts.packageName, "p", "", "default Go `package` for generated code")
+	fs.StringVar(&opts.outputName, "o", outputStdout, "default output `file`; - writes to standard output")
+	fs.Var(&opts.schemaPackages, "schema-package", "Go package for a schema, as `URI=PACKAGE` (repeatable)")
+	fs.Var(&opts.schemaOutputs, "schema-output", "output file for a schema, as `URI=FILE` (repeatable)")
+	fs.Var(&opts.schemaRootTypes, "schema-root-type", "root type name for a schema, as `URI=TYPE` (repeatable)")
+	fs.Var(&opts.tags, "tags", "struct `tags` to generate, comma-separated (default json,yaml,mapstructure)")
+	fs.Var(&opts.capitalizations, "capitalization", "`word` that should be fully capitalized in identifiers (repeatable)")
+	fs.Var(&opts.resolveExtensions, "resolve-extension", "file `extension` to try when resolving $ref paths (repeatable, default .json,.yaml,.yml)")
+	fs.Var(&opts.yamlExtensions, "yaml-extension", "file `extension` parsed as YAML (repeatable, default .yaml,.yml)")
+	fs.Var(&opts.mirrors, "mirror", "read the schemas under a URL prefix from a local directory, as `PREFIX=DIR` (repeatable)")
+	fs.Var(&opts.allowDirs, "allow-dir", "only load local schema files within `dir` (repeatable)")
+	fs.Var(&opts.allowSchemes, "allow-scheme", "only load remote schemas with a URL `scheme` such as https (repeatable)")
+	fs.Var(&opts.allowHosts, "allow-host", "only load remote schemas from a URL `host`, with its port if any (repeatable)")
+	fs.Int64Var(&opts.maxSchemaBytes, "max-schema-bytes", 0, "refuse schema documents larger than `n` bytes; 0 means no limit")
+	fs.IntVar(&opts.maxRefDepth, "max-ref-depth", 0, "refuse $refs more than `n` documents deep from an input; 0 means no limit")
+	fs.BoolVar(&opts.minSizedInts, "min-sized-ints", false, "use the smallest int type that fits the schema bounds")
+	fs.BoolVar(&opts.onlyModels, "only-models", false, "generate types only, without unmarshal and validation methods")
+	fs.BoolVar(&opts.extraImports, "extra-imports", false, "also generate YAML unmarshalers (imports gopkg.in/yaml.v3)")
+	fs.BoolVar(&opts.structNameFromTitle, "struct-name-from-title", false, "name root types after the schema title")
+	fs.BoolVar(&opts.skipValidation, "skip-schema-validation", false,
+		"generate without first checking the schemas against the meta-schema of their draft")
+	fs.BoolVar(&opts.verbose, "v", false, "log the files that are written")
+	fs.BoolVar(&opts.check, "check", false, "write nothing; fail with a diff if any generated file on disk is out of date")
+	fs.BoolVar(&opts.removeStale, "remove-stale", false, "delete generated files in the output directories that are no longer produced")
+	fs.BoolVar(&opts.watch, "watch", false, "keep running and regenerate whenever a loaded schema file changes")
+	fs.DurationVar(&opts.watchInterval, "watch-interval", 500*time.Millisecond, "how often to poll for changes in -watch mode")
+	fs.BoolVar(&opts.lint, "lint", false, "write nothing; report the schema constructs that the generated code will not honor")
+	fs.StringVar(&opts.lintFormat, "lint-format", lintFormatText, "`format` of -lint findings: text or json")
+	fs.StringVar(&opts.configFile, "config", "", "run every job in a project `file` (such as "+project.DefaultFileName+")")
+
+	return fs
+}
+
+func parseOptions(args []string, stderr io.Writer) (*options, error) {
+	opts := &options{}
+
+	fs := newFlagSet(opts, stderr)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	opts.inputs = fs.Args()
+
+	if len(opts.tags) == 0 {
+		opts.tags = stringList{"json", "yaml", "mapstructure"}
+	}
+
+	if len(opts.resolveExtensions) == 0 {
+		opts.resolveExtensions = stringList{".json", ".yaml", ".yml"}
+	}
+
+	if len(opts.yamlExtensions) == 0 {
+		opts.yamlExtensions = stringList{".yaml", ".yml"}
+	}
+
+	return opts, nil
+}
+
+func (o *options) config(warner func(string)) generator.Config {
+	mappings := map[string]*generator.SchemaMapping{}
+
+	mappingFor := func(uri string) *generator.SchemaMapping {
+		m, ok := mappings[uri]
+		if !ok {
+			m = &generator.SchemaMapping{SchemaID: uri}
+			mappings[uri] = m
+		}
+
+		return m
+	}
+
+	for _, m := range o.schemaPackages {
+		mappingFor(m.uri).PackageName = m.value
+	}
+
+	for _, m := range o.schemaOutputs {
+		mappingFor(m.uri).OutputName = m.value
+	}
+
+	for _, m := range o.schemaRootTypes {
+		mappingFor(m.uri).RootType = m.value
+	}
+
+	schemaMappings := make([]generator.SchemaMapping, 0, len(mappings))
+
+	for _, uri := range sortedKeys(mappings) {
+		m := mappings[uri]
+		if m.PackageName == "" {
+			m.PackageName = o.packageName
+		}
+
+		if m.OutputName == "" {
+			m.OutputName = o.outputName
+		}
+
+		schemaMappings = append(schemaMappings, *m)
+	}
+
+	return generator.Config{
+		SchemaMappings:      schemaMappings,
+		ExtraImports:        o.extraImports,
+		Capitalizations:     o.capitalizations,
+		ResolveExtensions:   o.resolveExtensions,
+		YAMLExtensions:      o.yamlExtensions,
+		DefaultPackageName:  o.packageName,
+		DefaultOutputName:   o.outputName,
+		StructNameFromTitle: o.structNameFromTitle,
+		Warner:              warner,
+		Tags:                o.tags,
+		OnlyModels:          o.onlyModels,
+		MinSizedInts:        o.minSizedInts,
+		ValidateSchemas:     !o.skipValidation,
+		Loader:              o.newLoader(),
+	}
+}
+
+// newLoader returns a caching schema loader that refuses the schemas that
+// the -allow and -max flags do not allow, and reads the -mirror prefixes from
+// their directories.
+func (o *options) newLoader() schemas.Loader {
+	loader := schemas.NewDefaultMultiLoader(o.resolveExtensions, o.yamlExtensions)
+
+	if policy, ok := o.policy(); ok {
+		loader.Restrict(policy, o.resolveExtensions)
+	}
+
+	if len(o.mirrors) > 0 {
+		mirrors := make(map[string]string, len(o.mirrors))
+		for _, m := range o.mirrors {
+			mirrors[m.uri] = m.value
+		}
+
+		loader.Mirror(mirrors, loader[schemas.RefTypeFile])
+	}
+
+	cached := schemas.NewCachedLoader(loader, map[string]*schemas.Schema{})
+	cached.ResolveExtensions = o.resolveExtensions
+
+	return cached
+}
+
+// policy returns the policy of the -allow and -max flags, if any is set.
+func (o *options) policy() (schemas.Policy, bool) {
+	policy := schemas.Policy{
+		Roots:    o.allowDirs,
+		Schemes:  o.allowSchemes,
+		Hosts:    o.allowHosts,
+		MaxBytes: o.maxSchemaBytes,
+		MaxDepth: o.maxRefDepth,
+	}
+
+	return policy, len(policy.Roots) > 0 || len(policy.Schemes) > 0 || len(policy.Hosts) > 0 ||
+		policy.MaxBytes > 0 || policy.MaxDepth > 0
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	opts, err := parseOptions(args, stderr)
+	if err != nil {
+		return err
+	}
+
+	logf := func(format string, args ...any) {
+		fmt.Fprintf(stderr, format+"\n", args...)
+	}
+
+	warner := func(message string) {
+		logf("warning: %s", message)
+	}
+
+	if opts.check && opts.removeStale {
+		return errCheckRemove
+	}
+
+	if opts.lint {
+		if opts.configFile != "" || opts.check || opts.removeStale || opts.watch {
+			return errLintMode
+		}
+
+		return lint(opts, stdout)
+	}
+
+	if opts.watch {
+		if opts.check {
+			return errWatchCheck
+		}
+
+		w, err := newWatcher(opts, stdout, logf)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		return w.watch(ctx, opts.watchInterval)
+	}
+
+	sources, err := generate(opts, warner)
+	if err != nil {
+		return err
+	}
+
+	if opts.check {
+		return reportStale(sources, stdout)
+	}
+
+	if _, err := writeSources(sources, stdout, logf, opts.verbose); err != nil {
+		return err
+	}
+
+	if opts.removeStale {
+		return removeStaleSources(sources, logf, opts.verbose)
+	}
+
+	return nil
+}
+
+func generate(opts *options, warner func(string)) (map[string][]byte, error) {
+	if opts.configFile != "" {
+		if len(opts.inputs) > 0 {
+			return nil, errConfigInputs
+		}
+
+		p, err := project.Load(opts.configFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return p.Generate(warner)
+	}
+
+	if len(opts.inputs) == 0 {
+		return nil, errNoInputs
+	}
+
+	if opts.packageName == "" && len(opts.schemaPackages) == 0 {
+		return nil, errNoPackageName
+	}
+
+	g, err := generator.New(opts.config(warner))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create generator: %w", err)
+	}
+
+	for _, input := range opts.inputs {
+		if err := g.DoFile(input); err != nil {
+			return nil, fmt.Errorf("failed to generate from %s: %w", input, err)
+		}
+	}
+
+	return g.Sources(), nil
+}
+
+// reportStale prints a diff for every generated file that differs from the
+// one on disk, and fails if there are any.
+func reportStale(sources map[string][]byte, stdout io.Writer) error {
+	stale, err := generator.CheckSources("", sources)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range stale {
+		status := "out of date"
+		if s.Missing {
+			status = "missing"
+		}
+
+		fmt.Fprintf(stdout, "%s: %s\n%s\n", s.FileName, status, s.Diff)
+	}
+
+	if len(stale) > 0 {
+		return fmt.Errorf("%w: %d file(s); run schema2go to regenerate", errStale, len(stale))
+	}
+
+	return nil
+}
+
+// writeSources writes the sources meant for standard output there, and the
+// others to the files on disk whose contents differ, and returns the names of
+// those files.
+func writeSources(
+	sources map[string][]byte, stdout io.Writer, logf func(string, ...any), verbose bool,
+) ([]string, error) {
+	if source, ok := sources[outputStdout]; ok {
+		if _, err := stdout.Write(source); err != nil {
+			return nil, fmt.Errorf("failed to write to standard output: %w", err)
+		}
+	}
+
+	written, err := generator.WriteSources("", sources)
+
+	if verbose {
+		for _, fileName := range written {
+			logf("wrote %s", fileName)
+		}
+	}
+
+	return written, err
+}
+
+func removeStaleSources(sources map[string][]byte, logf func(string, ...any), verbose bool) error {
+	removed, err := generator.RemoveStaleSources("", sources)
+
+	if verbose {
+		for _, fileName := range removed {
+			logf("removed %s", fileName)
+		}
+	}
+
+	return err
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
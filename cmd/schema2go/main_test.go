@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/walteh/schema2go/pkg/generator"
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+func TestConfigFromFlags(t *testing.T) {
+	opts, err := parseOptions([]string{
+		"-p", "github.com/example/def",
+		"-o", "out.go",
+		"-schema-package", "https://example.com/a=github.com/example/a",
+		"-schema-output", "https://example.com/a=a/a.go",
+		"-schema-root-type", "https://example.com/b?v=1=Bee",
+		"-tags", "json,yaml",
+		"-capitalization", "ID",
+		"-capitalization", "URL",
+		"-min-sized-ints",
+		"-only-models",
+		"schema.json",
+	}, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	cfg := opts.config(nil)
+
+	assert.Equal(t, []generator.SchemaMapping{
+		{
+			SchemaID:    "https://example.com/a",
+			PackageName: "github.com/example/a",
+			OutputName:  "a/a.go",
+		},
+		{
+			SchemaID:    "https://example.com/b?v=1",
+			PackageName: "github.com/example/def",
+			RootType:    "Bee",
+			OutputName:  "out.go",
+		},
+	}, cfg.SchemaMappings)
+	assert.Equal(t, "github.com/example/def", cfg.DefaultPackageName)
+	assert.Equal(t, "out.go", cfg.DefaultOutputName)
+	assert.Equal(t, []string{"json", "yaml"}, cfg.Tags)
+	assert.Equal(t, []string{"ID", "URL"}, cfg.Capitalizations)
+	assert.Equal(t, []string{".yaml", ".yml"}, cfg.YAMLExtensions)
+	assert.True(t, cfg.MinSizedInts)
+	assert.True(t, cfg.OnlyModels)
+	assert.False(t, cfg.ExtraImports)
+	assert.True(t, cfg.ValidateSchemas)
+	assert.Equal(t, []string{"schema.json"}, opts.inputs)
+}
+
+func TestInvalidMappingFlag(t *testing.T) {
+	_, err := parseOptions([]string{"-schema-package", "github.com/example/a"}, &bytes.Buffer{})
+	require.ErrorContains(t, err, errInvalidMapping.Error())
+}
+
+func TestRunWritesFiles(t *testing.T) {
+	input, err := filepath.Abs("../../tests/data/core/primitives/primitives.json")
+	require.NoError(t, err)
+
+	golden, err := os.ReadFile("../../tests/data/core/primitives/primitives.go")
+	require.NoError(t, err)
+
+	output := filepath.Join(t.TempDir(), "nested", "primitives.go")
+
+	var stdout, stderr bytes.Buffer
+
+	err = run([]string{
+		"-p", "github.com/example/test",
+		"-o", output,
+		"-extra-imports",
+		input,
+	}, &stdout, &stderr)
+	require.NoError(t, err, stderr.String())
+
+	got, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(golden), string(got))
+	assert.Empty(t, stdout.String())
+}
+
+func TestRunWritesToStdout(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	err := run([]string{"-p", "example", "../../tests/data/core/primitives/primitives.json"}, &stdout, &stderr)
+	require.NoError(t, err, stderr.String())
+
+	assert.Contains(t, stdout.String(), "package example")
+	assert.Contains(t, stdout.String(), "type Primitives struct")
+}
+
+func TestRunMirror(t *testing.T) {
+	golden, err := os.ReadFile("../../tests/data/mirror/schema/schema.go")
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+
+	err = run([]string{
+		"-p", "github.com/example/test",
+		"-extra-imports",
+		"-mirror", "https://schemas.example.com/=../../tests/data/mirror/vendor/",
+		"../../tests/data/mirror/schema/schema.json",
+	}, &stdout, &stderr)
+	require.NoError(t, err, stderr.String())
+
+	assert.Equal(t, string(golden), stdout.String())
+}
+
+func TestRunMirrorDirectory(t *testing.T) {
+	golden, err := os.ReadFile("../../tests/data/mirror/schema/schema.go")
+	require.NoError(t, err)
+
+	// The directory contains "=" and is given without a trailing "/".
+	vendor := filepath.Join(t.TempDir(), "vendor=v1")
+	require.NoError(t, os.CopyFS(vendor, os.DirFS("../../tests/data/mirror/vendor")))
+
+	var stdout, stderr bytes.Buffer
+
+	err = run([]string{
+		"-p", "github.com/example/test",
+		"-extra-imports",
+		"-mirror", "https://schemas.example.com/=" + vendor,
+		"../../tests/data/mirror/schema/schema.json",
+	}, &stdout, &stderr)
+	require.NoError(t, err, stderr.String())
+
+	assert.Equal(t, string(golden), stdout.String())
+}
+
+func TestRunPolicy(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "schemas", "a.json")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "schemas"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "other"), 0o755))
+	require.NoError(t, os.WriteFile(input,
+		[]byte(`{"type": "object", "properties": {"b": {"$ref": "../other/b.json"}}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other", "b.json"), []byte(`{"type": "string"}`), 0o644))
+
+	var stdout, stderr bytes.Buffer
+
+	err := run([]string{"-p", "example", "-allow-dir", filepath.Join(dir, "schemas"), input}, &stdout, &stderr)
+	require.ErrorIs(t, err, schemas.ErrOutsideRoots)
+	assert.Contains(t, err.Error(), `refused to load $ref "../other/b.json"`)
+
+	err = run([]string{"-p", "example", "-allow-dir", dir, "-max-ref-depth", "1", input}, &stdout, &stderr)
+	require.NoError(t, err, stderr.String())
+
+	err = run([]string{"-p", "example", "-allow-dir", dir, "-max-ref-depth", "0", "-max-schema-bytes", "10", input},
+		&stdout, &stderr)
+	require.ErrorIs(t, err, schemas.ErrDocumentTooLarge)
+}
+
+func TestRunRequiresInputsAndPackage(t *testing.T) {
+	require.ErrorIs(t, run([]string{"-p", "example"}, &bytes.Buffer{}, &bytes.Buffer{}), errNoInputs)
+	require.ErrorIs(t, run([]string{"schema.json"}, &bytes.Buffer{}, &bytes.Buffer{}), errNoPackageName)
+}
+
+func TestRunWithConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	schema, err := os.ReadFile("../../tests/data/core/primitives/primitives.json")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "primitives.json"), schema, 0o644))
+
+	config := filepath.Join(dir, "schema2go.yaml")
+	require.NoError(t, os.WriteFile(config, []byte(`
+jobs:
+  - package: github.com/example/test
+    output: gen/primitives.go
+    inputs: [primitives.json]
+    options: {extraImports: true}
+`), 0o644))
+
+	var stdout, stderr bytes.Buffer
+
+	require.NoError(t, run([]string{"-config", config}, &stdout, &stderr), stderr.String())
+
+	golden, err := os.ReadFile("../../tests/data/core/primitives/primitives.go")
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(filepath.Join(dir, "gen", "primitives.go"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(golden), string(got))
+
+	require.ErrorIs(t, run([]string{"-config", config, "other.json"}, &stdout, &stderr), errConfigInputs)
+}
+
+func TestRunCheck(t *testing.T) {
+	input := "../../tests/data/core/primitives/primitives.json"
+	output := filepath.Join(t.TempDir(), "primitives.go")
+	args := []string{"-p", "github.com/example/test", "-o", output, "-extra-imports", input}
+
+	var stdout, stderr bytes.Buffer
+
+	err := run(append([]string{"-check"}, args...), &stdout, &stderr)
+	require.ErrorIs(t, err, errStale)
+	assert.Contains(t, stdout.String(), output+": missing")
+	assert.NoFileExists(t, output, "check mode must not write anything")
+
+	require.NoError(t, run(args, &stdout, &stderr))
+
+	stdout.Reset()
+	require.NoError(t, run(append([]string{"-check"}, args...), &stdout, &stderr))
+	assert.Empty(t, stdout.String())
+
+	require.NoError(t, os.WriteFile(output, []byte("package test\n"), 0o644))
+
+	err = run(append([]string{"-check"}, args...), &stdout, &stderr)
+	require.ErrorIs(t, err, errStale)
+	assert.Contains(t, stdout.String(), output+": out of date")
+	assert.Contains(t, stdout.String(), "+type Primitives struct {")
+}
+
+func TestRunRemoveStale(t *testing.T) {
+	dir := t.TempDir()
+	input := "../../tests/data/core/primitives/primitives.json"
+	output := filepath.Join(dir, "primitives.go")
+	stale := filepath.Join(dir, "old.go")
+
+	var stdout, stderr bytes.Buffer
+
+	require.NoError(t, run([]string{"-p", "test", "-o", stale, input}, &stdout, &stderr))
+	require.NoError(t, run([]string{"-p", "test", "-o", output, input}, &stdout, &stderr))
+	assert.FileExists(t, stale, "stale files are kept unless asked otherwise")
+
+	require.NoError(t, run([]string{"-p", "test", "-o", output, "-remove-stale", "-v", input}, &stdout, &stderr))
+	assert.NoFileExists(t, stale)
+	assert.FileExists(t, output)
+	assert.Contains(t, stderr.String(), "removed "+stale)
+
+	require.ErrorIs(t, run([]string{"-check", "-remove-stale", input}, &stdout, &stderr), errCheckRemove)
+}
+
+func TestRunLint(t *testing.T) {
+	input := "../../tests/data/lint/lint.yaml"
+
+	var stdout, stderr bytes.Buffer
+
+	err := run([]string{"-lint", input}, &stdout, &stderr)
+	require.ErrorIs(t, err, errLintFindings)
+	assert.Contains(t, stdout.String(),
+		input+":10:3 (#/properties/count): maxLength does not apply to type integer and is ignored [inapplicable-keyword]\n")
+
+	stdout.Reset()
+
+	err = run([]string{"-lint", "-lint-format", "json", input}, &stdout, &stderr)
+	require.ErrorIs(t, err, errLintFindings)
+
+	var findings []generator.Finding
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &findings))
+	require.Len(t, findings, 11)
+	assert.Equal(t, generator.RuleMisspelledKeyword, findings[0].Rule)
+	assert.Contains(t, stdout.String(), `"pointer": "/properties/both"`)
+
+	stdout.Reset()
+
+	require.NoError(t, run([]string{"-lint", "../../tests/data/core/primitives/primitives.json"}, &stdout, &stderr))
+	assert.Empty(t, stdout.String())
+
+	require.ErrorIs(t, run([]string{"-lint", "-lint-format", "xml", input}, &stdout, &stderr), errLintFormat)
+	require.ErrorIs(t, run([]string{"-lint", "-check", input}, &stdout, &stderr), errLintMode)
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/walteh/schema2go/pkg/generator"
+	"github.com/walteh/schema2go/pkg/project"
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+var errWatchStdin = errors.New("cannot watch standard input")
+
+// watchJob is a unit of generation that is re-run as a whole when any of the
+// files it loaded change.
+type watchJob struct {
+	name string
+	run  func(loader schemas.Loader, warner func(string)) (map[string][]byte, error)
+
+	newLoader         func() schemas.Loader
+	resolveExtensions []string
+
+	files   map[string]fileStamp
+	sources map[string][]byte
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+func stampOf(fileName string) fileStamp {
+	info, err := os.Stat(fileName)
+	if err != nil {
+		return fileStamp{}
+	}
+
+	return fileStamp{modTime: info.ModTime(), size: info.Size(), exists: true}
+}
+
+type watcher struct {
+	jobs             []*watchJob
+	stdout           io.Writer
+	logf             func(string, ...any)
+	verbose          bool
+	removeStaleFiles bool
+}
+
+func newWatcher(opts *options, stdout io.Writer, logf func(string, ...any)) (*watcher, error) {
+	w := &watcher{stdout: stdout, logf: logf, verbose: opts.verbose, removeStaleFiles: opts.removeStale}
+
+	if opts.configFile != "" {
+		if len(opts.inputs) > 0 {
+			return nil, errConfigInputs
+		}
+
+		p, err := project.Load(opts.configFile)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range p.Jobs {
+			w.jobs = append(w.jobs, &watchJob{
+				name: p.JobName(i),
+				run: func(loader schemas.Loader, warner func(string)) (map[string][]byte, error) {
+					return p.GenerateJob(i, loader, warner)
+				},
+				newLoader:         func() schemas.Loader { return p.NewLoader() },
+				resolveExtensions: p.ResolveExtensions,
+			})
+		}
+
+		return w, nil
+	}
+
+	if len(opts.inputs) == 0 {
+		return nil, errNoInputs
+	}
+
+	if opts.packageName == "" && len(opts.schemaPackages) == 0 {
+		return nil, errNoPackageName
+	}
+
+	for _, input := range opts.inputs {
+		if input == outputStdout {
+			return nil, errWatchStdin
+		}
+	}
+
+	w.jobs = append(w.jobs, &watchJob{
+		name: strings.Join(opts.inputs, ", "),
+		run: func(loader schemas.Loader, warner func(string)) (map[string][]byte, error) {
+			cfg := opts.config(warner)
+			cfg.Loader = loader
+
+			g, err := generator.New(cfg)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create generator: %w", err)
+			}
+
+			for _, input := range opts.inputs {
+				if err := g.DoFile(input); err != nil {
+					return nil, fmt.Errorf("failed to generate from %s: %w", input, err)
+				}
+			}
+
+			return g.Sources(), nil
+		},
+		newLoader:         opts.newLoader,
+		resolveExtensions: opts.resolveExtensions,
+	})
+
+	return w, nil
+}
+
+// watch regenerates every job once, then polls the files each job loaded and
+// regenerates the jobs whose files changed, until the context is cancelled.
+func (w *watcher) watch(ctx context.Context, interval time.Duration) error {
+	w.generateAll()
+
+	w.logf("watching %d file(s) for changes", w.fileCount())
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+func (w *watcher) generateAll() {
+	for _, job := range w.jobs {
+		w.regenerate(job)
+	}
+
+	w.removeStale()
+}
+
+// poll regenerates the jobs that loaded a file that changed since their last
+// run, and reports how many were regenerated.
+func (w *watcher) poll() int {
+	regenerated := 0
+
+	for _, job := range w.jobs {
+		var changed []string
+
+		for fileName, stamp := range job.files {
+			if stampOf(fileName) != stamp {
+				changed = append(changed, fileName)
+			}
+		}
+
+		if len(changed) == 0 {
+			continue
+		}
+
+		sort.Strings(changed)
+
+		w.logf("%s changed; regenerating %s", strings.Join(changed, ", "), job.name)
+		w.regenerate(job)
+
+		regenerated++
+	}
+
+	if regenerated > 0 {
+		w.removeStale()
+	}
+
+	return regenerated
+}
+
+// regenerate runs a job with a fresh loader, so that changed files are
+// re-read, and writes the outputs whose contents differ from the files on
+// disk.
+func (w *watcher) regenerate(job *watchJob) {
+	loader := schemas.NewTrackingLoader(job.newLoader(), job.resolveExtensions)
+
+	warnings := 0
+
+	sources, err := job.run(loader, func(message string) {
+		warnings++
+
+		w.logf("warning: %s: %s", job.name, message)
+	})
+
+	// Keep watching the files from the previous run as well; a failed run stops
+	// early and may never have reached some of them.
+	files := map[string]fileStamp{}
+
+	for fileName := range job.files {
+		files[fileName] = stampOf(fileName)
+	}
+
+	for _, fileName := range loader.Files() {
+		files[fileName] = stampOf(fileName)
+	}
+
+	job.files = files
+
+	if err != nil {
+		w.logf("error: %v", err)
+
+		return
+	}
+
+	job.sources = sources
+
+	written, err := writeSources(sources, w.stdout, w.logf, w.verbose)
+	if err != nil {
+		w.logf("error: %v", err)
+
+		return
+	}
+
+	w.logf("generated %s: %d file(s) written, %d warning(s)", job.name, len(written), warnings)
+}
+
+// removeStale deletes the generated files that no job produces, if asked to,
+// once all jobs of a pass have run: the outputs of other jobs may live in the
+// same directories. Nothing is deleted until every job has succeeded once,
+// since the outputs of the others are unknown until then.
+func (w *watcher) removeStale() {
+	if !w.removeStaleFiles {
+		return
+	}
+
+	for _, job := range w.jobs {
+		if job.sources == nil {
+			w.logf("not removing stale files until %s is generated", job.name)
+
+			return
+		}
+	}
+
+	if err := removeStaleSources(w.sources(), w.logf, w.verbose); err != nil {
+		w.logf("error: %v", err)
+	}
+}
+
+// sources returns the latest outputs of every job.
+func (w *watcher) sources() map[string][]byte {
+	sources := map[string][]byte{}
+
+	for _, job := range w.jobs {
+		for fileName, source := range job.sources {
+			sources[fileName] = source
+		}
+	}
+
+	return sources
+}
+
+// fileCount returns the number of watched files that exist; the others are
+// only watched to notice when they are created.
+func (w *watcher) fileCount() int {
+	files := map[string]bool{}
+
+	for _, job := range w.jobs {
+		for fileName, stamp := range job.files {
+			if stamp.exists {
+				files[fileName] = true
+			}
+		}
+	}
+
+	return len(files)
+}
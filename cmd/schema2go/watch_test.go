@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchRegeneratesOnReferencedFileChange(t *testing.T) {
+	dir := t.TempDir()
+
+	root := filepath.Join(dir, "root.json")
+	child := filepath.Join(dir, "defs", "child.json")
+	unrelated := filepath.Join(dir, "unrelated.json")
+	output := filepath.Join(dir, "out", "types.go")
+
+	writeTestFile(t, root, `{"type": "object", "properties": {"child": {"$ref": "defs/child.json"}}}`)
+	writeTestFile(t, child, `{"type": "object", "properties": {"first": {"type": "string"}}}`)
+	writeTestFile(t, unrelated, `{"type": "object"}`)
+
+	opts, err := parseOptions([]string{"-p", "example", "-o", output, root}, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	var log bytes.Buffer
+
+	w, err := newWatcher(opts, &bytes.Buffer{}, func(format string, args ...any) {
+		fmt.Fprintf(&log, format+"\n", args...)
+	})
+	require.NoError(t, err)
+
+	w.generateAll()
+	assert.Equal(t, 2, w.fileCount(), "the root schema and its transitive $ref target are watched")
+	assert.Contains(t, readTestFile(t, output), "First *string")
+
+	assert.Zero(t, w.poll(), "nothing changed yet")
+
+	touchTestFile(t, unrelated, `{"type": "object", "properties": {}}`)
+	assert.Zero(t, w.poll(), "files that were never loaded do not trigger a run")
+
+	touchTestFile(t, child, `{"type": "object", "properties": {"second": {"type": "integer"}}}`)
+	assert.Equal(t, 1, w.poll())
+	assert.Contains(t, readTestFile(t, output), "Second *int")
+	assert.Zero(t, w.poll())
+
+	touchTestFile(t, child, `{"type": "object", "properties": {"broken": {"$ref": "missing.json"}}}`)
+	assert.Equal(t, 1, w.poll())
+	assert.Contains(t, log.String(), "missing.json", "errors are reported and watching continues")
+	assert.Contains(t, readTestFile(t, output), "Second *int", "a failed run leaves the output alone")
+
+	writeTestFile(t, filepath.Join(dir, "defs", "missing.json"), `{"type": "string"}`)
+	assert.Equal(t, 1, w.poll(), "creating a file that failed to load triggers a run")
+	assert.Contains(t, readTestFile(t, output), "Broken *Missing")
+
+	touchTestFile(t, child, `{"type": "object", "properties": {"third": {"type": "boolean"}}}`)
+	assert.Equal(t, 1, w.poll())
+	assert.Contains(t, readTestFile(t, output), "Third *bool")
+}
+
+func TestWatchRegeneratesOnMirroredFileChange(t *testing.T) {
+	dir := t.TempDir()
+
+	root := filepath.Join(dir, "root.json")
+	mirrored := filepath.Join(dir, "vendor", "child.json")
+	output := filepath.Join(dir, "types.go")
+
+	writeTestFile(t, root, `{"type": "object", "properties": {"child": {"$ref": "https://schemas.example.com/child.json"}}}`)
+	writeTestFile(t, mirrored, `{"type": "object", "properties": {"first": {"type": "string"}}}`)
+
+	opts, err := parseOptions([]string{
+		"-p", "example", "-o", output, "-mirror", "https://schemas.example.com/=" + filepath.Join(dir, "vendor") + "/", root,
+	}, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	w, err := newWatcher(opts, &bytes.Buffer{}, func(string, ...any) {})
+	require.NoError(t, err)
+
+	w.generateAll()
+	assert.Equal(t, 2, w.fileCount(), "the mirror of a remote $ref target is watched")
+	assert.Contains(t, readTestFile(t, output), "First *string")
+
+	touchTestFile(t, mirrored, `{"type": "object", "properties": {"second": {"type": "integer"}}}`)
+	assert.Equal(t, 1, w.poll())
+	assert.Contains(t, readTestFile(t, output), "Second *int")
+}
+
+func TestWatchRewritesOutputsChangedOnDisk(t *testing.T) {
+	dir := t.TempDir()
+
+	root := filepath.Join(dir, "root.json")
+	output := filepath.Join(dir, "types.go")
+
+	writeTestFile(t, root, `{"type": "object", "properties": {"first": {"type": "string"}}}`)
+
+	opts, err := parseOptions([]string{"-p", "example", "-o", output, root}, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	w, err := newWatcher(opts, &bytes.Buffer{}, func(string, ...any) {})
+	require.NoError(t, err)
+
+	w.generateAll()
+
+	generated := readTestFile(t, output)
+	writeTestFile(t, output, "package example\n")
+
+	// The sources are the same as those of the previous run, but no longer
+	// those on disk.
+	touchTestFile(t, root, `{"type": "object", "properties": {"first": {"type": "string"}}}`)
+	assert.Equal(t, 1, w.poll())
+	assert.Equal(t, generated, readTestFile(t, output))
+}
+
+func TestWatchRemovesStaleFilesAfterAllJobs(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFile(t, filepath.Join(dir, "a.json"), `{"type": "object", "properties": {"a": {"type": "string"}}}`)
+	writeTestFile(t, filepath.Join(dir, "b.json"), `{"type": "object", "properties": {"b": {"type": "string"}}}`)
+	writeTestFile(t, filepath.Join(dir, "gen", "old.go"), "// Code generated by schema2go. DO NOT EDIT.\n\npackage gen\n")
+
+	// Both jobs write to the same directory; the first one must not sweep the
+	// output of the second before it is generated.
+	config := filepath.Join(dir, "schema2go.yaml")
+	writeTestFile(t, config, `
+jobs:
+  - package: example/gen
+    output: gen/a.go
+    inputs: [a.json]
+  - package: example/gen
+    output: gen/b.go
+    inputs: [b.json]
+`)
+	writeTestFile(t, filepath.Join(dir, "gen", "b.go"), "// Code generated by schema2go. DO NOT EDIT.\n\npackage gen\n")
+
+	opts, err := parseOptions([]string{"-config", config, "-remove-stale", "-v"}, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	var log bytes.Buffer
+
+	w, err := newWatcher(opts, &bytes.Buffer{}, func(format string, args ...any) {
+		fmt.Fprintf(&log, format+"\n", args...)
+	})
+	require.NoError(t, err)
+
+	w.generateAll()
+	assert.NotContains(t, log.String(), "error")
+	assert.FileExists(t, filepath.Join(dir, "gen", "a.go"))
+	assert.Contains(t, readTestFile(t, filepath.Join(dir, "gen", "b.go")), "B *string")
+	assert.NoFileExists(t, filepath.Join(dir, "gen", "old.go"))
+	assert.Contains(t, log.String(), "removed "+filepath.Join(dir, "gen", "old.go"))
+	assert.NotContains(t, log.String(), "removed "+filepath.Join(dir, "gen", "b.go"))
+}
+
+func TestWatchRejectsStdin(t *testing.T) {
+	opts, err := parseOptions([]string{"-p", "example", "-"}, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	_, err = newWatcher(opts, &bytes.Buffer{}, func(string, ...any) {})
+	require.ErrorIs(t, err, errWatchStdin)
+}
+
+func writeTestFile(t *testing.T, fileName, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0o755))
+	require.NoError(t, os.WriteFile(fileName, []byte(content), 0o644))
+}
+
+// touchTestFile rewrites a file and moves its modification time forward, so
+// the change is seen even on file systems with coarse timestamps.
+func touchTestFile(t *testing.T, fileName, content string) {
+	t.Helper()
+
+	info, err := os.Stat(fileName)
+	require.NoError(t, err)
+
+	writeTestFile(t, fileName, content)
+
+	later := info.ModTime().Add(time.Second)
+	require.NoError(t, os.Chtimes(fileName, later, later))
+}
+
+func readTestFile(t *testing.T, fileName string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+
+	return string(data)
+}
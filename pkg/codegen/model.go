@@ -21,13 +21,17 @@ type Named interface {
 	GetName() string
 }
 
+// GeneratedComment marks a file as generated by schema2go. It follows the
+// convention described in https://go.dev/s/generatedcode.
+const GeneratedComment = "Code generated by schema2go. DO NOT EDIT."
+
 type File struct {
 	FileName string
 	Package  Package
 }
 
 func (p *File) Generate(out *Emitter) {
-	out.Comment("Code generated by github.com/walteh/schema2go, DO NOT EDIT.")
+	out.Comment(GeneratedComment)
 	out.Newline()
 	p.Package.Generate(out)
 }
@@ -42,8 +42,8 @@ func PrimitiveTypeFromJSONSchemaType(
 	minIntSize bool,
 	minimum **float64,
 	maximum **float64,
-	exclusiveMinimum **any,
-	exclusiveMaximum **any,
+	exclusiveMinimum **float64,
+	exclusiveMaximum **float64,
 ) (Type, error) {
 	var t Type
 
@@ -137,12 +137,12 @@ func PrimitiveTypeFromJSONSchemaType(
 
 			if removeMin {
 				*minimum = nil
-				*exclusiveMaximum = nil
+				*exclusiveMinimum = nil
 			}
 
 			if removeMax {
 				*maximum = nil
-				*exclusiveMinimum = nil
+				*exclusiveMaximum = nil
 			}
 		}
 
@@ -172,18 +172,21 @@ func PrimitiveTypeFromJSONSchemaType(
 
 // getMinIntType returns the smallest integer type that can represent the bounds, and if the bounds can be removed.
 func getMinIntType(
-	minimum, maximum *float64, exclusiveMinimum, exclusiveMaximum *any,
+	minimum, maximum *float64, exclusiveMinimum, exclusiveMaximum *float64,
 ) (string, bool, bool) {
 	nMin, nMax, nExclusiveMin, nExclusiveMax := mathutils.NormalizeBounds(
 		minimum, maximum, exclusiveMinimum, exclusiveMaximum,
 	)
 
+	// The bounds may point into the schema, so they are not adjusted in place.
 	if nExclusiveMin && nMin != nil {
-		*nMin += 1.0
+		inclusive := *nMin + 1.0
+		nMin = &inclusive
 	}
 
 	if nExclusiveMax && nMax != nil {
-		*nMax -= 1.0
+		inclusive := *nMax - 1.0
+		nMax = &inclusive
 	}
 
 	if nMin != nil && *nMin >= 0 {
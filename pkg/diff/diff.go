@@ -30,18 +30,21 @@ func TypedDiff[T any](want T, got T) string {
 }
 
 func diffd(want string, got string) string {
+	return UnifiedDiff("Expected", "Actual", want, got, 5)
+}
+
+// UnifiedDiff returns an uncolored unified diff from one text to another, or
+// an empty string if they are equal.
+func UnifiedDiff(fromFile, toFile, from, to string, context int) string {
 	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
-		A:        difflib.SplitLines(want),
-		B:        difflib.SplitLines(got),
-		FromFile: "Expected",
-		FromDate: "",
-		ToFile:   "Actual",
-		ToDate:   "",
-		Context:  5,
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  context,
 	})
 
 	return diff
-
 }
 
 // formatStartingWhitespace formats leading whitespace characters to be visible while maintaining proper spacing
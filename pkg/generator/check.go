@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+
+	"github.com/walteh/schema2go/pkg/diff"
+)
+
+const checkDiffContext = 3
+
+// StaleSource describes a generated file whose contents on disk differ from
+// what the generator produces.
+type StaleSource struct {
+	FileName string
+	// Missing is set when the file does not exist on disk at all.
+	Missing bool
+	// Diff is a unified diff from the file on disk to the generated source.
+	Diff string
+}
+
+// Check compares every generated source with the file on disk and reports
+// the ones that are missing or out of date; see CheckSources.
+func (g *Generator) Check(dir string) ([]StaleSource, error) {
+	return CheckSources(dir, g.Sources())
+}
+
+// CheckSources compares generated sources, keyed by file name, with the files
+// on disk. Relative file names are resolved against dir, as in WriteSources,
+// and sources meant for standard output ("-") are skipped.
+func CheckSources(dir string, sources map[string][]byte) ([]StaleSource, error) {
+	fileNames := make([]string, 0, len(sources))
+
+	for fileName := range sources {
+		if fileName != "-" {
+			fileNames = append(fileNames, fileName)
+		}
+	}
+
+	sort.Strings(fileNames)
+
+	var stale []StaleSource
+
+	for _, name := range fileNames {
+		fileName := outputPath(dir, name)
+		generated := string(sources[name])
+
+		current, err := os.ReadFile(fileName)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("failed to read %s: %w", fileName, err)
+			}
+
+			stale = append(stale, StaleSource{
+				FileName: fileName,
+				Missing:  true,
+				Diff:     diff.UnifiedDiff("/dev/null", fileName, "", generated, checkDiffContext),
+			})
+
+			continue
+		}
+
+		if string(current) == generated {
+			continue
+		}
+
+		stale = append(stale, StaleSource{
+			FileName: fileName,
+			Diff:     diff.UnifiedDiff(fileName, fileName+" (generated)", string(current), generated, checkDiffContext),
+		})
+	}
+
+	return stale, nil
+}
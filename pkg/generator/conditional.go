@@ -0,0 +1,678 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/walteh/schema2go/pkg/codegen"
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+// schemaCheck is a single constraint of a subschema that can be checked
+// against the raw value of an object, before it is unmarshaled.
+type schemaCheck struct {
+	jsonName string
+	kind     checkKind
+	// emit checks the value of the property, when present. Presence itself
+	// is checked by checkRequired.
+	emit    func(out *codegen.Emitter, value string, fail func())
+	imports []string
+	// holds describes the check as a condition, e.g. `kind is "s3"`.
+	holds string
+	// violation describes a failed check, e.g. `must be "s3"`.
+	violation string
+}
+
+type checkKind int
+
+const (
+	checkRequired checkKind = iota
+	checkType
+	// checkValue narrows the value further than its type.
+	checkValue
+	// checkExact allows only particular values, as const and enum do.
+	checkExact
+)
+
+// Keywords that schema checks understand, by the JSON name of the field of
+// schemas.Type. Annotations are listed as well, since they never fail.
+var (
+	objectCheckKeywords = map[string]struct{}{
+		"required":   {},
+		"properties": {},
+	}
+	valueCheckKeywords = map[string]struct{}{
+		"type":      {},
+		"const":     {},
+		"enum":      {},
+		"pattern":   {},
+		"minLength": {},
+		"maxLength": {},
+	}
+	annotationKeywords = map[string]struct{}{
+		"$schema":     {},
+		"$id":         {},
+		"$comment":    {},
+		"title":       {},
+		"description": {},
+		"default":     {},
+		"format":      {},
+	}
+)
+
+// uncheckedKeywords returns the keywords of a subschema that schemaChecks
+// cannot check, with JSON pointers relative to t.
+func uncheckedKeywords(t *schemas.Type) []string {
+	unchecked := keywordsNotIn(t, objectCheckKeywords)
+
+	for _, name := range sortedKeys(t.Properties) {
+		for _, keyword := range keywordsNotIn(t.Properties[name], valueCheckKeywords) {
+			unchecked = append(unchecked, "properties/"+escapePointerToken(name)+"/"+keyword)
+		}
+	}
+
+	return unchecked
+}
+
+func keywordsNotIn(t *schemas.Type, supported map[string]struct{}) []string {
+	var keywords []string
+
+	if t.IsFalse() {
+		return []string{"false"}
+	}
+
+	v := reflect.ValueOf(*t)
+
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "" || name == "-" || v.Field(i).IsZero() {
+			continue
+		}
+
+		_, ok := supported[name]
+		if _, annotation := annotationKeywords[name]; !ok && !annotation {
+			keywords = append(keywords, name)
+		}
+	}
+
+	return keywords
+}
+
+// keywordsIn returns the keywords of t that are in the set.
+func keywordsIn(t *schemas.Type, set map[string]struct{}) []string {
+	var keywords []string
+
+	for _, keyword := range keywordsNotIn(t, nil) {
+		if _, ok := set[keyword]; ok {
+			keywords = append(keywords, keyword)
+		}
+	}
+
+	return keywords
+}
+
+// schemaChecks returns the checks of an object subschema, as far as it
+// consists of keywords listed in objectCheckKeywords and valueCheckKeywords.
+func schemaChecks(t *schemas.Type) []schemaCheck {
+	var checks []schemaCheck
+
+	for _, name := range t.Required {
+		checks = append(checks, schemaCheck{
+			jsonName:  name,
+			kind:      checkRequired,
+			holds:     name + " is set",
+			violation: "required",
+		})
+	}
+
+	for _, name := range sortedKeys(t.Properties) {
+		checks = append(checks, valueChecks(name, t.Properties[name])...)
+	}
+
+	return checks
+}
+
+func valueChecks(name string, t *schemas.Type) []schemaCheck {
+	var checks []schemaCheck
+
+	if len(t.Type) > 0 {
+		checks = append(checks, typeCheck(name, t.Type))
+	}
+
+	if t.Const != nil {
+		// Values are compared as JSON, which also equates YAML integers with
+		// JSON numbers.
+		canonical, _ := json.Marshal(*t.Const) //nolint:errchkjson // decoded from JSON
+
+		checks = append(checks, schemaCheck{
+			jsonName: name,
+			emit: func(out *codegen.Emitter, value string, fail func()) {
+				out.Printlnf(`if b, err := json.Marshal(%s); err != nil || string(b) != %s {`,
+					value, strconv.Quote(string(canonical)))
+				out.Indent(1)
+				fail()
+				out.Indent(-1)
+				out.Printlnf("}")
+			},
+			kind:      checkExact,
+			imports:   []string{"encoding/json"},
+			holds:     fmt.Sprintf("%s is %s", name, canonical),
+			violation: fmt.Sprintf("must be %s", canonical),
+		})
+	}
+
+	if len(t.Enum) > 0 {
+		values := make([]string, len(t.Enum))
+		for i, v := range t.Enum {
+			b, _ := json.Marshal(v) //nolint:errchkjson // decoded from JSON
+			values[i] = strconv.Quote(string(b))
+		}
+
+		canonical, _ := json.Marshal(t.Enum) //nolint:errchkjson // decoded from JSON
+
+		checks = append(checks, schemaCheck{
+			jsonName: name,
+			emit: func(out *codegen.Emitter, value string, fail func()) {
+				out.Printlnf(`if b, err := json.Marshal(%s); err != nil || !slices.Contains([]string{%s}, string(b)) {`,
+					value, strings.Join(values, ", "))
+				out.Indent(1)
+				fail()
+				out.Indent(-1)
+				out.Printlnf("}")
+			},
+			kind:      checkExact,
+			imports:   []string{"encoding/json", "slices"},
+			holds:     fmt.Sprintf("%s is one of %s", name, canonical),
+			violation: fmt.Sprintf("must be one of %s", canonical),
+		})
+	}
+
+	if t.Pattern != "" {
+		checks = append(checks, schemaCheck{
+			jsonName: name,
+			emit: func(out *codegen.Emitter, value string, fail func()) {
+				out.Printlnf(`if s, ok := %s.(string); ok {`, value)
+				out.Indent(1)
+				out.Printlnf(`if matched, _ := regexp.MatchString(`+"`%s`"+`, s); !matched {`, t.Pattern)
+				out.Indent(1)
+				fail()
+				out.Indent(-1)
+				out.Printlnf("}")
+				out.Indent(-1)
+				out.Printlnf("}")
+			},
+			kind:      checkValue,
+			imports:   []string{"regexp"},
+			holds:     fmt.Sprintf("%s matches %s", name, t.Pattern),
+			violation: fmt.Sprintf("must match %s", t.Pattern),
+		})
+	}
+
+	for _, bound := range []struct {
+		length int
+		op     string
+	}{
+		{t.MinLength, ">="},
+		{t.MaxLength, "<="},
+	} {
+		if bound.length == 0 {
+			continue
+		}
+
+		checks = append(checks, schemaCheck{
+			jsonName: name,
+			emit: func(out *codegen.Emitter, value string, fail func()) {
+				out.Printlnf(`if s, ok := %s.(string); ok && !(len(s) %s %d) {`, value, bound.op, bound.length)
+				out.Indent(1)
+				fail()
+				out.Indent(-1)
+				out.Printlnf("}")
+			},
+			kind:      checkValue,
+			holds:     fmt.Sprintf("%s length is %s %d", name, bound.op, bound.length),
+			violation: fmt.Sprintf("length must be %s %d", bound.op, bound.length),
+		})
+	}
+
+	return checks
+}
+
+// typeCheck checks the type of a raw value, as decoded from either JSON or
+// YAML.
+func typeCheck(name string, types schemas.TypeList) schemaCheck {
+	var (
+		cases   []string
+		integer bool
+		names   []string
+	)
+
+	for _, typeName := range types {
+		switch typeName {
+		case schemas.TypeNameString:
+			cases = append(cases, "string")
+			names = append(names, "a string")
+
+		case schemas.TypeNameBoolean:
+			cases = append(cases, "bool")
+			names = append(names, "a boolean")
+
+		case schemas.TypeNameInteger:
+			cases = append(cases, "int", "int64", "uint64", "float64")
+			names = append(names, "an integer")
+			integer = !slices.Contains(types, schemas.TypeNameNumber)
+
+		case schemas.TypeNameNumber:
+			if !slices.Contains(types, schemas.TypeNameInteger) {
+				cases = append(cases, "int", "int64", "uint64", "float64")
+			}
+
+			names = append(names, "a number")
+
+		case schemas.TypeNameObject:
+			cases = append(cases, "map[string]interface{}")
+			names = append(names, "an object")
+
+		case schemas.TypeNameArray:
+			cases = append(cases, "[]interface{}")
+			names = append(names, "an array")
+
+		case schemas.TypeNameNull:
+			cases = append(cases, "nil")
+			names = append(names, "null")
+		}
+	}
+
+	description := strings.Join(names, " or ")
+
+	return schemaCheck{
+		jsonName: name,
+		kind:     checkType,
+		emit: func(out *codegen.Emitter, value string, fail func()) {
+			out.Printlnf("switch %s.(type) {", value)
+			out.Printlnf("case %s:", strings.Join(cases, ", "))
+
+			if integer {
+				out.Indent(1)
+				out.Printlnf("if f, ok := %s.(float64); ok && f != float64(int64(f)) {", value)
+				out.Indent(1)
+				fail()
+				out.Indent(-1)
+				out.Printlnf("}")
+				out.Indent(-1)
+			}
+
+			out.Printlnf("default:")
+			out.Indent(1)
+			fail()
+			out.Indent(-1)
+			out.Printlnf("}")
+		},
+		holds:     fmt.Sprintf("%s is %s", name, description),
+		violation: "must be " + description,
+	}
+}
+
+// emitCheck emits a check against the raw map of an object.
+func (c schemaCheck) emitCheck(out *codegen.Emitter, fail func()) {
+	if c.kind == checkRequired {
+		out.Printlnf(`if _, ok := %s["%s"]; !ok {`, varNameRawMap, c.jsonName)
+		out.Indent(1)
+		fail()
+		out.Indent(-1)
+		out.Printlnf("}")
+
+		return
+	}
+
+	out.Printlnf(`if v, ok := %s["%s"]; ok {`, varNameRawMap, c.jsonName)
+	out.Indent(1)
+	c.emit(out, "v", fail)
+	out.Indent(-1)
+	out.Printlnf("}")
+}
+
+// emitChecksOrFail emits checks against the raw map of an object that return
+// an error naming the condition under which they apply.
+func emitChecksOrFail(out *codegen.Emitter, checks []schemaCheck, condition string) {
+	for _, check := range checks {
+		check.emitCheck(out, func() {
+			out.Printlnf(`return fmt.Errorf("field %%s: %%s %%s", "%s", %s, %s)`,
+				check.jsonName, strconv.Quote(check.violation), strconv.Quote(condition))
+		})
+	}
+}
+
+// conditionalValidators returns a validator for every if/then/else of a
+// type, including those of the types it was merged from.
+func (g *schemaGenerator) conditionalValidators(t *schemas.Type) []validator {
+	var validators []validator
+
+	for _, c := range t.Conditions() {
+		if len(uncheckedKeywords(c.If)) > 0 {
+			continue
+		}
+
+		v := &conditionalValidator{
+			index:    len(validators),
+			ifChecks: schemaChecks(c.If),
+		}
+
+		if c.Then != nil {
+			v.thenChecks = schemaChecks(c.Then)
+		}
+
+		if c.Else != nil {
+			v.elseChecks = schemaChecks(c.Else)
+		}
+
+		if len(v.thenChecks) > 0 || len(v.elseChecks) > 0 {
+			validators = append(validators, v)
+		}
+	}
+
+	for _, v := range validators {
+		for _, check := range v.(*conditionalValidator).checks() {
+			for _, pkg := range check.imports {
+				g.output.file.Package.AddImport(pkg, "")
+			}
+		}
+	}
+
+	return validators
+}
+
+// dependentValidators returns a validator for every property that has
+// dependentRequired or dependentSchemas.
+func (g *schemaGenerator) dependentValidators(t *schemas.Type) []validator {
+	names := sortedKeys(t.DependentRequired)
+
+	for _, name := range sortedKeys(t.DependentSchemas) {
+		if _, ok := t.DependentRequired[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	var validators []validator
+
+	for _, name := range names {
+		v := &dependentValidator{jsonName: name}
+
+		for _, required := range t.DependentRequired[name] {
+			v.checks = append(v.checks, schemaCheck{
+				jsonName:  required,
+				kind:      checkRequired,
+				holds:     required + " is set",
+				violation: "required",
+			})
+		}
+
+		if schema, ok := t.DependentSchemas[name]; ok {
+			v.checks = append(v.checks, schemaChecks(schema)...)
+		}
+
+		if len(v.checks) == 0 {
+			continue
+		}
+
+		for _, check := range v.checks {
+			for _, pkg := range check.imports {
+				g.output.file.Package.AddImport(pkg, "")
+			}
+		}
+
+		validators = append(validators, v)
+	}
+
+	return validators
+}
+
+type dependentValidator struct {
+	jsonName string
+	checks   []schemaCheck
+}
+
+func (v *dependentValidator) generate(out *codegen.Emitter, format string) {
+	out.Printlnf(`if _, ok := %s["%s"]; ok {`, varNameRawMap, v.jsonName)
+	out.Indent(1)
+	emitChecksOrFail(out, v.checks, fmt.Sprintf("when %s is set", v.jsonName))
+	out.Indent(-1)
+	out.Printlnf("}")
+}
+
+func (v *dependentValidator) desc() *validatorDesc {
+	return &validatorDesc{
+		hasError:            true,
+		beforeJSONUnmarshal: true,
+	}
+}
+
+// isObjectNot reports whether a not schema forbids combinations of the
+// properties of an object, which the object itself validates.
+func isObjectNot(not *schemas.Type) bool {
+	return not != nil && len(not.Required)+len(not.Properties) > 0 && len(uncheckedKeywords(not)) == 0
+}
+
+// isValueNot reports whether a not schema forbids values of a property,
+// which the object containing it validates.
+func isValueNot(not *schemas.Type) bool {
+	return not != nil && !isObjectNot(not) && len(keywordsNotIn(not, valueCheckKeywords)) == 0
+}
+
+// notValidators returns validators for the not schema of an object, whether
+// it is declared as a struct or a map, and for those of its properties.
+func (g *schemaGenerator) notValidators(declName string, t *schemas.Type) []validator {
+	var validators []validator
+
+	add := func(subject, value string, checks []schemaCheck) {
+		for _, check := range checks {
+			for _, pkg := range check.imports {
+				g.output.file.Package.AddImport(pkg, "")
+			}
+		}
+
+		validators = append(validators, &notValidator{
+			index:   len(validators),
+			subject: subject,
+			checks:  checks,
+			value:   value,
+		})
+	}
+
+	if isObjectNot(t.Not) {
+		add(declName, "", schemaChecks(t.Not))
+	} else if isValueNot(t.Not) {
+		add(declName, varNameRawMap, valueChecks("value", t.Not))
+	}
+
+	for _, name := range sortedKeys(t.Properties) {
+		if not := t.Properties[name].Not; isValueNot(not) {
+			add("field "+name, "", schemaChecks(&schemas.Type{
+				Required:   []string{name},
+				Properties: map[string]*schemas.Type{name: not},
+			}))
+		}
+	}
+
+	return validators
+}
+
+// valueNotValidator returns a validator for the not schema of a declared
+// type, which checks the given value: the unmarshaled value, or the raw map
+// of an object.
+func (g *schemaGenerator) valueNotValidator(declName, value string, not *schemas.Type) validator {
+	checks := valueChecks("value", not)
+	for _, check := range checks {
+		for _, pkg := range check.imports {
+			g.output.file.Package.AddImport(pkg, "")
+		}
+	}
+
+	return &notValidator{
+		subject: declName,
+		checks:  checks,
+		value:   value,
+	}
+}
+
+// notValidator rejects a value that passes all checks of a not schema.
+type notValidator struct {
+	// index tells apart the not validators of the same type.
+	index   int
+	subject string
+	checks  []schemaCheck
+	// value is the value to check as a whole; without it, the checks apply
+	// to the properties in the raw map of an object.
+	value string
+}
+
+func (v *notValidator) generate(out *codegen.Emitter, format string) {
+	matched := "notMatched"
+	if v.index > 0 {
+		matched += strconv.Itoa(v.index)
+	}
+
+	condition := describeChecks(v.checks)
+
+	out.Printlnf("%s := true", matched)
+
+	fail := func() {
+		out.Printlnf("%s = false", matched)
+	}
+
+	if v.value != "" {
+		out.Printlnf("var v interface{} = %s", v.value)
+
+		for _, check := range v.checks {
+			check.emit(out, "v", fail)
+		}
+	} else {
+		for _, check := range v.checks {
+			check.emitCheck(out, fail)
+		}
+	}
+
+	out.Printlnf("if %s {", matched)
+	out.Indent(1)
+	out.Printlnf(`return fmt.Errorf("%%s: must not match %%s", "%s", %s)`, v.subject, strconv.Quote(condition))
+	out.Indent(-1)
+	out.Printlnf("}")
+}
+
+func (v *notValidator) desc() *validatorDesc {
+	return &validatorDesc{
+		hasError:            true,
+		beforeJSONUnmarshal: v.value == "",
+		requiresRawAfter:    v.value == varNameRawMap,
+	}
+}
+
+type conditionalValidator struct {
+	// index tells apart the conditionals of the same type.
+	index      int
+	ifChecks   []schemaCheck
+	thenChecks []schemaCheck
+	elseChecks []schemaCheck
+}
+
+func (v *conditionalValidator) checks() []schemaCheck {
+	checks := append([]schemaCheck{}, v.ifChecks...)
+	checks = append(checks, v.thenChecks...)
+
+	return append(checks, v.elseChecks...)
+}
+
+func (v *conditionalValidator) generate(out *codegen.Emitter, format string) {
+	matched := "ifMatched"
+	if v.index > 0 {
+		matched += strconv.Itoa(v.index)
+	}
+
+	condition := describeChecks(v.ifChecks)
+
+	out.Printlnf("%s := true", matched)
+
+	for _, check := range v.ifChecks {
+		check.emitCheck(out, func() {
+			out.Printlnf("%s = false", matched)
+		})
+	}
+
+	branch := func(checks []schemaCheck, clause string) {
+		emitChecksOrFail(out, checks, clause+" "+condition)
+	}
+
+	switch {
+	case len(v.thenChecks) > 0 && len(v.elseChecks) > 0:
+		out.Printlnf("if %s {", matched)
+		out.Indent(1)
+		branch(v.thenChecks, "when")
+		out.Indent(-1)
+		out.Printlnf("} else {")
+		out.Indent(1)
+		branch(v.elseChecks, "unless")
+		out.Indent(-1)
+		out.Printlnf("}")
+
+	case len(v.thenChecks) > 0:
+		out.Printlnf("if %s {", matched)
+		out.Indent(1)
+		branch(v.thenChecks, "when")
+		out.Indent(-1)
+		out.Printlnf("}")
+
+	default:
+		out.Printlnf("if !%s {", matched)
+		out.Indent(1)
+		branch(v.elseChecks, "unless")
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+}
+
+func (v *conditionalValidator) desc() *validatorDesc {
+	return &validatorDesc{
+		hasError:            true,
+		beforeJSONUnmarshal: true,
+	}
+}
+
+// describeChecks describes the condition that a list of checks amounts to,
+// leaving out the checks that others imply.
+func describeChecks(checks []schemaCheck) string {
+	strongest := map[string]checkKind{}
+	for _, check := range checks {
+		strongest[check.jsonName] = max(strongest[check.jsonName], check.kind)
+	}
+
+	var conditions []string
+
+	for _, check := range checks {
+		switch strongest[check.jsonName] {
+		case checkValue, checkExact:
+			if check.kind == checkRequired || check.kind == checkType {
+				continue
+			}
+
+		case checkType:
+			if check.kind == checkRequired {
+				continue
+			}
+		}
+
+		conditions = append(conditions, check.holds)
+	}
+
+	if len(conditions) == 0 {
+		return "the if schema matches"
+	}
+
+	return strings.Join(conditions, " and ")
+}
@@ -16,6 +16,10 @@ type Config struct {
 	OnlyModels          bool
 	MinSizedInts        bool
 	Loader              schemas.Loader
+	// ValidateSchemas checks every document against the meta-schema of its
+	// dialect before generating code for it, and warns about keywords that
+	// look like misspellings of its own.
+	ValidateSchemas bool
 }
 
 type SchemaMapping struct {
@@ -0,0 +1,281 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/sanity-io/litter"
+
+	"github.com/walteh/schema2go/pkg/codegen"
+)
+
+// constField is a struct field whose value is fixed by a const keyword.
+type constField struct {
+	fieldName    string
+	constantName string
+	isNillable   bool
+}
+
+// addConstants declares a typed Go constant for every const value of the
+// declared type, or of its fields, that has a primitive Go type. It returns
+// the fields that have such a constant.
+func (g *schemaGenerator) addConstants(decl *codegen.TypeDecl) []constField {
+	if decl.SchemaType != nil && decl.SchemaType.Const != nil {
+		if value, ok := constGoValue(decl.Type, *decl.SchemaType.Const); ok {
+			g.output.file.Package.AddDecl(&codegen.Constant{
+				Name:  g.output.uniqueValueName(decl.Name+"Value", g.origin(decl.SchemaType)),
+				Type:  &codegen.NamedType{Decl: decl},
+				Value: value,
+			})
+		}
+	}
+
+	structType, ok := decl.Type.(*codegen.StructType)
+	if !ok {
+		return nil
+	}
+
+	var fields []constField
+
+	for _, f := range structType.Fields {
+		if f.SchemaType == nil || f.SchemaType.Const == nil {
+			continue
+		}
+
+		fieldType, isNillable := f.Type, false
+		if p, ok := fieldType.(*codegen.PointerType); ok {
+			fieldType, isNillable = p.Type, true
+		}
+
+		value, ok := constGoValue(fieldType, *f.SchemaType.Const)
+		if !ok {
+			continue
+		}
+
+		constant := &codegen.Constant{
+			Name:  g.output.uniqueValueName(decl.Name+f.Name, g.origin(f.SchemaType)),
+			Type:  fieldType,
+			Value: value,
+		}
+		g.output.file.Package.AddDecl(constant)
+
+		fields = append(fields, constField{
+			fieldName:    f.Name,
+			constantName: constant.Name,
+			isNillable:   isNillable,
+		})
+	}
+
+	return fields
+}
+
+// newConstValidator returns a validator that checks a field, or the declared
+// type itself when the field has no name, against its const value. The
+// JSON name of a declared type is its own name, for error messages.
+func newConstValidator(f codegen.StructField) *constValidator {
+	value := *f.SchemaType.Const
+
+	// Const values are decoded from JSON, so they always marshal.
+	canonical, _ := json.Marshal(value) //nolint:errchkjson // see above
+
+	v := &constValidator{
+		jsonName:  f.JSONName,
+		fieldName: f.Name,
+		canonical: string(canonical),
+	}
+
+	fieldType := f.Type
+	if p, ok := fieldType.(*codegen.PointerType); ok {
+		fieldType, v.isNillable = p.Type, true
+	}
+
+	// A declared type is checked through its plain value, which cannot be
+	// compared with a typed constant; the literal works for both.
+	if goValue, ok := constGoValue(fieldType, value); ok {
+		v.literal = litter.Sdump(goValue)
+	}
+
+	return v
+}
+
+// constValueName returns the part of a constant's name that stands for its
+// value. Values other than strings are named after their JSON type as well,
+// so that 1 and "1" do not map to the same name.
+func (g *schemaGenerator) constValueName(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "Null"
+
+	case string:
+		return g.caser.Identifierize(v)
+
+	case bool:
+		return g.caser.Identifierize("bool " + strconv.FormatBool(v))
+
+	case float64:
+		return g.caser.Identifierize("number " + strconv.FormatFloat(v, 'f', -1, 64))
+
+	case []any:
+		return "Array"
+
+	default:
+		return "Object"
+	}
+}
+
+// constGoValue converts a const value to the Go value of the given primitive
+// type, if it is one and the value fits it.
+func constGoValue(t codegen.Type, value any) (any, bool) {
+	var typeName string
+
+	switch pt := t.(type) {
+	case codegen.PrimitiveType:
+		typeName = pt.Type
+
+	case *codegen.PrimitiveType:
+		typeName = pt.Type
+
+	case *codegen.NamedType:
+		if pt.Decl == nil {
+			return nil, false
+		}
+
+		return constGoValue(pt.Decl.Type, value)
+
+	default:
+		return nil, false
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, typeName == "string"
+
+	case bool:
+		return v, typeName == "bool"
+
+	case float64:
+		switch {
+		case strings.HasPrefix(typeName, "float"):
+			return v, true
+
+		case strings.HasPrefix(typeName, "uint"):
+			return uint64(v), v == math.Trunc(v) && v >= 0
+
+		case strings.HasPrefix(typeName, "int"):
+			return int64(v), v == math.Trunc(v)
+		}
+	}
+
+	return nil, false
+}
+
+type constValidator struct {
+	jsonName   string
+	fieldName  string
+	isNillable bool
+	// literal is the Go literal of the value when the field has a matching
+	// primitive type; otherwise the raw value is compared as JSON.
+	literal   string
+	canonical string
+	// value is the value of a declared type to compare as JSON instead of
+	// the field in the raw map of an object.
+	value string
+}
+
+func (v *constValidator) generate(out *codegen.Emitter, format string) {
+	if v.literal == "" && v.value != "" {
+		out.Printlnf(`if b, err := json.Marshal(%s); err != nil || string(b) != %s {`, v.value, strconv.Quote(v.canonical))
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("field %%s: must be %%s", "%s", %s)`, v.jsonName, strconv.Quote(v.canonical))
+		out.Indent(-1)
+		out.Printlnf("}")
+
+		return
+	}
+
+	if v.literal == "" {
+		out.Printlnf(`if v, ok := %s["%s"]; ok {`, varNameRawMap, v.jsonName)
+		out.Indent(1)
+		out.Printlnf(`if b, err := json.Marshal(v); err != nil || string(b) != %s {`, strconv.Quote(v.canonical))
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("field %%s: must be %%s", "%s", %s)`, v.jsonName, strconv.Quote(v.canonical))
+		out.Indent(-1)
+		out.Printlnf("}")
+		out.Indent(-1)
+		out.Printlnf("}")
+
+		return
+	}
+
+	value := getPlainName(v.fieldName)
+
+	if v.isNillable {
+		out.Printlnf(`if %s != nil && *%s != %s {`, value, value, v.literal)
+	} else {
+		out.Printlnf(`if %s != %s {`, value, v.literal)
+	}
+
+	out.Indent(1)
+	out.Printlnf(`return fmt.Errorf("field %%s: must be %%s", "%s", %s)`, v.jsonName, strconv.Quote(v.canonical))
+	out.Indent(-1)
+	out.Printlnf("}")
+}
+
+func (v *constValidator) desc() *validatorDesc {
+	return &validatorDesc{
+		hasError:            true,
+		beforeJSONUnmarshal: v.literal == "" && v.value == "",
+		requiresRawAfter:    v.literal == "" && v.value == varNameRawMap,
+	}
+}
+
+// plainTypeName returns a name for the local copy of a declared type that
+// marshalers and unmarshalers use to avoid recursing into themselves.
+func plainTypeName(o *output, declName string) string {
+	tp := typePlain
+
+	if tp == declName {
+		for i := 0; !o.isUniqueTypeName(tp) && i < math.MaxInt; i++ {
+			tp = fmt.Sprintf("%s_%d", typePlain, i)
+		}
+	}
+
+	return tp
+}
+
+// marshalerPurpose describes what a generated marshaler adds to the default
+// marshaling.
+func marshalerPurpose(constFields []constField, patternFields []patternField) string {
+	var purposes []string
+
+	if len(constFields) > 0 {
+		purposes = append(purposes, "fills in the fields that have a const value")
+	}
+
+	if len(patternFields) > 0 {
+		purposes = append(purposes, "adds the properties whose names match a pattern")
+	}
+
+	return "it " + strings.Join(purposes, " and ")
+}
+
+// emitMarshalPlain declares the plain copy of the value being marshaled, with
+// its const fields filled in.
+func emitMarshalPlain(out *codegen.Emitter, o *output, declType codegen.TypeDecl, constFields []constField) {
+	tp := plainTypeName(o, declType.Name)
+
+	out.Printlnf("type %s %s", tp, declType.Name)
+	out.Printlnf("%s := %s(j)", varNamePlainStruct, tp)
+
+	for _, f := range constFields {
+		if f.isNillable {
+			out.Printlnf("const%s := %s", f.fieldName, f.constantName)
+			out.Printlnf("%s = &const%s", getPlainName(f.fieldName), f.fieldName)
+		} else {
+			out.Printlnf("%s = %s", getPlainName(f.fieldName), f.constantName)
+		}
+	}
+}
@@ -9,6 +9,12 @@ type formatter interface {
 
 	generate(output *output, declType codegen.TypeDecl, validators []validator) func(*codegen.Emitter)
 	enumMarshal(declType codegen.TypeDecl) func(*codegen.Emitter)
+	marshal(
+		output *output,
+		declType codegen.TypeDecl,
+		constFields []constField,
+		patternFields []patternField,
+	) func(*codegen.Emitter)
 	enumUnmarshal(
 		declType codegen.TypeDecl,
 		enumType codegen.Type,
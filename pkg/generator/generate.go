@@ -1,9 +1,11 @@
 package generator
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"go/format"
+	"io"
 	"os"
 	"strings"
 
@@ -15,6 +17,7 @@ import (
 const (
 	varNamePlainStruct = "plain"
 	varNameRawMap      = "raw"
+	varNamePlainValue  = "plainValue"
 	interfaceTypeName  = "interface{}"
 	typePlain          = "Plain"
 )
@@ -40,6 +43,25 @@ type Generator struct {
 	warner     func(string)
 	formatters []formatter
 	loader     schemas.Loader
+	prepared   map[*schemas.Schema]struct{}
+	// clones holds the copy of each schema that the loader returned, which
+	// the generator prepares instead, since the loader may share the schema
+	// with other generators.
+	clones map[*schemas.Schema]*schemas.Schema
+	// resources holds the schemas with an $id, including embedded ones, of
+	// every document loaded so far.
+	resources map[string]resource
+	// remoteURIs holds the URLs of the documents loaded over HTTP or from a
+	// mirror of it, against which their relative $refs are resolved.
+	remoteURIs map[*schemas.Schema]string
+	// ctx is the context of the DoFile, DoReader or DoBytes call in progress.
+	ctx context.Context //nolint:containedctx // scoped to a single Do call
+}
+
+type resource struct {
+	schemas.Resource
+	schema   *schemas.Schema
+	fileName string
 }
 
 type qualifiedDefinition struct {
@@ -65,6 +87,11 @@ func New(config Config) (*Generator, error) {
 		warner:     config.Warner,
 		formatters: formatters,
 		loader:     config.Loader,
+		prepared:   map[*schemas.Schema]struct{}{},
+		clones:     map[*schemas.Schema]*schemas.Schema{},
+		resources:  map[string]resource{},
+		remoteURIs: map[*schemas.Schema]string{},
+		ctx:        context.Background(),
 	}
 
 	if config.Loader == nil {
@@ -115,27 +142,101 @@ func (g *Generator) Sources() map[string][]byte {
 	return result
 }
 
+// DoFile generates code for the schema in the given file. The file name "-"
+// reads the schema from standard input, as JSON or YAML.
 func (g *Generator) DoFile(fileName string) error {
-	var err error
-
-	var schema *schemas.Schema
-
 	if fileName == "-" {
-		schema, err = schemas.FromJSONReader(os.Stdin)
-		if err != nil {
+		if err := g.DoReader(context.Background(), fileName, os.Stdin); err != nil {
 			return fmt.Errorf("error parsing from standard input: %w", err)
 		}
-	} else {
-		schema, err = g.loader.Load(fileName, "")
-		if err != nil {
-			return fmt.Errorf("error parsing from file %s: %w", fileName, err)
-		}
+
+		return nil
+	}
+
+	schema, err := g.load(fileName, "")
+	if err != nil {
+		return fmt.Errorf("error parsing from file %s: %w", fileName, err)
 	}
 
 	return g.addFile(fileName, schema)
 }
 
+// DoReader generates code for a JSON or YAML schema read from r. The uri is
+// the schema's logical location: relative $refs are resolved against it, and
+// it names the root type unless the schema has a title or mapping for that.
+// Loading stops with the context's error once ctx is done.
+func (g *Generator) DoReader(ctx context.Context, uri string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", uri, err)
+	}
+
+	return g.DoBytes(ctx, uri, data)
+}
+
+// DoBytes is like DoReader, but takes the schema's contents directly.
+func (g *Generator) DoBytes(ctx context.Context, uri string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	schema, err := schemas.FromBytes(data)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", uri, err)
+	}
+
+	schema.SetSource(uri, data)
+
+	previous := g.ctx
+	g.ctx = ctx
+
+	defer func() {
+		g.ctx = previous
+	}()
+
+	return g.addFile(uri, schema)
+}
+
+// load loads a schema through the configured loader, giving up once the
+// current context is done.
+func (g *Generator) load(uri, parentURI string) (*schemas.Schema, error) {
+	loaded, err := schemas.LoadContext(g.ctx, g.loader, uri, parentURI)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, ok := g.clones[loaded]
+	if !ok {
+		schema = loaded.Clone()
+		g.clones[loaded] = schema
+	}
+
+	if ref, err := schemas.GetRefType(uri); err == nil && (ref == schemas.RefTypeHTTP || ref == schemas.RefTypeHTTPS) {
+		g.remoteURIs[schema] = uri
+	}
+
+	return schema, nil
+}
+
 func (g *Generator) addFile(fileName string, schema *schemas.Schema) error {
+	if _, ok := g.prepared[schema]; !ok && g.config.ValidateSchemas {
+		misspellings, err := schema.LintMeta()
+		if err != nil {
+			return err
+		}
+
+		for _, v := range misspellings {
+			origin := v.Origin
+			if origin.File == "" {
+				origin.File = fileName
+			}
+
+			g.warner(locatedMessage(origin, v.Message))
+		}
+	}
+
+	g.prepareSchema(fileName, schema)
+
 	o, err := g.findOutputFileForSchemaID(schema.ID)
 	if err != nil {
 		return err
@@ -205,6 +306,8 @@ func (g *Generator) beginOutput(
 		},
 		declsBySchema: map[*schemas.Type]*codegen.TypeDecl{},
 		declsByName:   map[string]*codegen.TypeDecl{},
+		valueNames:    map[string]struct{}{},
+		patternFields: map[*schemas.Type][]patternField{},
 	}
 	g.outputs[id] = output
 
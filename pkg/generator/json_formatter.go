@@ -106,6 +106,33 @@ func (jf *jsonFormatter) enumMarshal(declType codegen.TypeDecl) func(*codegen.Em
 	}
 }
 
+func (jf *jsonFormatter) marshal(
+	output *output,
+	declType codegen.TypeDecl,
+	constFields []constField,
+	patternFields []patternField,
+) func(*codegen.Emitter) {
+	return func(out *codegen.Emitter) {
+		out.Commentf("Marshal%s implements %s.Marshaler; %s.",
+			strings.ToUpper(formatJSON), formatJSON, marshalerPurpose(constFields, patternFields))
+		out.Printlnf("func (j %s) Marshal%s() ([]byte, error) {", declType.Name, strings.ToUpper(formatJSON))
+		out.Indent(1)
+		emitMarshalPlain(out, output, declType, constFields)
+
+		if len(patternFields) == 0 {
+			out.Printlnf("return %s.Marshal(%s)", formatJSON, varNamePlainStruct)
+		} else {
+			out.Printlnf("b, err := %s.Marshal(%s)", formatJSON, varNamePlainStruct)
+			out.Printlnf("if err != nil { return nil, err }")
+			emitAddPatternProperties(out, formatJSON, patternFields)
+			out.Printlnf("return %s.Marshal(properties)", formatJSON)
+		}
+
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+}
+
 func (jf *jsonFormatter) enumUnmarshal(
 	declType codegen.TypeDecl,
 	enumType codegen.Type,
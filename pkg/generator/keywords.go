@@ -0,0 +1,499 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/walteh/schema2go/pkg/cmputil"
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+var (
+	// unsupportedKeywords are never enforced nor used.
+	unsupportedKeywords = map[string]struct{}{
+		"media":          {},
+		"binaryEncoding": {},
+		"$dynamicAnchor": {},
+	}
+	// elementValueKeywords constrain values without affecting their Go type.
+	elementValueKeywords = map[string]struct{}{
+		"const":            {},
+		"not":              {},
+		"contains":         {},
+		"minContains":      {},
+		"maxContains":      {},
+		"minLength":        {},
+		"maxLength":        {},
+		"pattern":          {},
+		"multipleOf":       {},
+		"maximum":          {},
+		"exclusiveMaximum": {},
+		"minimum":          {},
+		"exclusiveMinimum": {},
+		"minItems":         {},
+		"maxItems":         {},
+		"uniqueItems":      {},
+	}
+	// typeKeywords are the keywords that only apply to values of some types;
+	// the generated code ignores them for a Go type of any other.
+	typeKeywords = map[string][]string{
+		"minLength":             {schemas.TypeNameString},
+		"maxLength":             {schemas.TypeNameString},
+		"pattern":               {schemas.TypeNameString},
+		"multipleOf":            {schemas.TypeNameNumber, schemas.TypeNameInteger},
+		"maximum":               {schemas.TypeNameNumber, schemas.TypeNameInteger},
+		"exclusiveMaximum":      {schemas.TypeNameNumber, schemas.TypeNameInteger},
+		"minimum":               {schemas.TypeNameNumber, schemas.TypeNameInteger},
+		"exclusiveMinimum":      {schemas.TypeNameNumber, schemas.TypeNameInteger},
+		"items":                 {schemas.TypeNameArray},
+		"prefixItems":           {schemas.TypeNameArray},
+		"additionalItems":       {schemas.TypeNameArray},
+		"unevaluatedItems":      {schemas.TypeNameArray},
+		"minItems":              {schemas.TypeNameArray},
+		"maxItems":              {schemas.TypeNameArray},
+		"uniqueItems":           {schemas.TypeNameArray},
+		"contains":              {schemas.TypeNameArray},
+		"minContains":           {schemas.TypeNameArray},
+		"maxContains":           {schemas.TypeNameArray},
+		"properties":            {schemas.TypeNameObject},
+		"patternProperties":     {schemas.TypeNameObject},
+		"additionalProperties":  {schemas.TypeNameObject},
+		"unevaluatedProperties": {schemas.TypeNameObject},
+		"required":              {schemas.TypeNameObject},
+		"minProperties":         {schemas.TypeNameObject},
+		"maxProperties":         {schemas.TypeNameObject},
+		"propertyNames":         {schemas.TypeNameObject},
+		"dependentRequired":     {schemas.TypeNameObject},
+		"dependentSchemas":      {schemas.TypeNameObject},
+	}
+)
+
+// prepareSchema adapts the draft 2019-09 and 2020-12 keywords of a schema to
+// what the generator understands, warns about the ones it cannot enforce or
+// that its dialect does not have, and indexes its resources by $id. Each schema is only prepared once, however
+// often it is referenced.
+func (g *Generator) prepareSchema(fileName string, schema *schemas.Schema) {
+	if _, ok := g.prepared[schema]; ok {
+		return
+	}
+
+	g.prepared[schema] = struct{}{}
+
+	for _, w := range schema.Normalize() {
+		var t *schemas.Type
+		if resolved, err := schema.ResolvePointer(w.Pointer); err == nil {
+			t = resolved
+		}
+
+		g.warner(locatedMessage(typeOrigin(t, fileName, w.Pointer), w.Message))
+	}
+
+	if schema.ObjectAsType != nil {
+		g.prepareType(fileName, "", (*schemas.Type)(schema.ObjectAsType))
+	}
+
+	for _, name := range sortedKeys(schema.Definitions) {
+		g.prepareType(fileName, "/$defs/"+escapePointerToken(name), schema.Definitions[name])
+	}
+
+	// The first document to declare an $id wins.
+	for uri, r := range schema.IndexResources() {
+		if _, ok := g.resources[uri]; !ok {
+			g.resources[uri] = resource{Resource: r, schema: schema, fileName: fileName}
+		}
+	}
+}
+
+func (g *Generator) prepareType(fileName, pointer string, t *schemas.Type) {
+	if t == nil {
+		return
+	}
+
+	warn := func(format string, args ...any) {
+		g.warner(locatedMessage(typeOrigin(t, fileName, pointer), fmt.Sprintf(format, args...)))
+	}
+
+	children := func(keyword string, types []*schemas.Type) {
+		for i, child := range types {
+			g.prepareType(fileName, fmt.Sprintf("%s/%s/%d", pointer, keyword, i), child)
+		}
+	}
+
+	named := func(keyword string, types map[string]*schemas.Type) {
+		for _, name := range sortedKeys(types) {
+			g.prepareType(fileName, pointer+"/"+keyword+"/"+escapePointerToken(name), types[name])
+		}
+	}
+
+	named("properties", t.Properties)
+	named("patternProperties", t.PatternProperties)
+	named("dependentSchemas", t.DependentSchemas)
+	named("$defs", t.Definitions)
+	children("prefixItems", t.PrefixItems)
+	children("allOf", t.AllOf)
+	children("anyOf", t.AnyOf)
+	children("oneOf", t.OneOf)
+
+	g.prepareType(fileName, pointer+"/additionalProperties", t.AdditionalProperties)
+	g.prepareType(fileName, pointer+"/additionalItems", t.AdditionalItems)
+	g.prepareType(fileName, pointer+"/items", t.Items)
+	g.prepareType(fileName, pointer+"/contains", t.Contains)
+	g.prepareType(fileName, pointer+"/propertyNames", t.PropertyNames)
+	g.prepareType(fileName, pointer+"/unevaluatedProperties", t.UnevaluatedProperties)
+	g.prepareType(fileName, pointer+"/unevaluatedItems", t.UnevaluatedItems)
+	g.prepareType(fileName, pointer+"/not", t.Not)
+	g.prepareType(fileName, pointer+"/if", t.If)
+	g.prepareType(fileName, pointer+"/then", t.Then)
+	g.prepareType(fileName, pointer+"/else", t.Else)
+
+	for _, message := range unhonoredKeywords(t) {
+		warn("%s", message)
+	}
+
+	for _, message := range inapplicableKeywords(t) {
+		warn("%s", message)
+	}
+
+	// Properties that no other keyword evaluates are exactly the additional
+	// ones, since subschemas are merged into a single type anyway.
+	if t.UnevaluatedProperties != nil && t.AdditionalProperties == nil {
+		t.AdditionalProperties = t.UnevaluatedProperties
+	}
+
+	if t.UnevaluatedItems != nil && t.Items == nil {
+		t.Items = t.UnevaluatedItems
+	}
+
+	if t.DynamicRef != "" && t.Ref == "" {
+		t.Ref = t.DynamicRef
+	}
+
+	// A const without a type still determines the type of its value.
+	if t.Const != nil && len(t.Type) == 0 && t.Ref == "" {
+		t.Type = schemas.TypeList{constTypeName(*t.Const)}
+	}
+
+	// With items set to false, nothing may follow the prefixItems.
+	if len(t.PrefixItems) > 0 && t.Items.IsFalse() && t.MaxItems == 0 {
+		t.MaxItems = len(t.PrefixItems)
+	}
+
+	g.prepareConditional(fileName, pointer, t)
+	g.prepareDependencies(fileName, pointer, t)
+
+	if len(t.PrefixItems) > 0 && arrayItemsType(t) == nil {
+		warn("prefixItems have differing types; items will be represented as interface{}")
+	}
+
+}
+
+// prepareConditional adds the properties of then and else to the type as
+// optional fields.
+func (g *Generator) prepareConditional(fileName, pointer string, t *schemas.Type) {
+	if t.If == nil {
+		return
+	}
+
+	warn := func(format string, args ...any) {
+		g.warner(locatedMessage(typeOrigin(t, fileName, pointer), fmt.Sprintf(format, args...)))
+	}
+
+	var branches []*schemas.Type
+
+	for _, branch := range []*schemas.Type{t.Then, t.Else} {
+		if branch != nil {
+			branches = append(branches, branch)
+		}
+	}
+
+	mergeConditionalProperties(t, branches, warn)
+}
+
+// prepareDependencies adds the properties of dependentSchemas to the type as
+// optional fields.
+func (g *Generator) prepareDependencies(fileName, pointer string, t *schemas.Type) {
+	if len(t.DependentSchemas) == 0 {
+		return
+	}
+
+	warn := func(format string, args ...any) {
+		g.warner(locatedMessage(typeOrigin(t, fileName, pointer), fmt.Sprintf(format, args...)))
+	}
+
+	subschemas := make([]*schemas.Type, 0, len(t.DependentSchemas))
+
+	for _, name := range sortedKeys(t.DependentSchemas) {
+		subschemas = append(subschemas, t.DependentSchemas[name])
+	}
+
+	mergeConditionalProperties(t, subschemas, warn)
+}
+
+// unhonoredKeywords describes the keywords of t, not of its subschemas, that
+// the generated code does not enforce or that have no effect. It is called
+// before the type is prepared.
+func unhonoredKeywords(t *schemas.Type) []string {
+	var messages []string
+
+	add := func(format string, args ...any) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}
+
+	for _, keyword := range keywordsIn(t, unsupportedKeywords) {
+		add("%s is not supported and is ignored", keyword)
+	}
+
+	if t.UnevaluatedProperties != nil && t.AdditionalProperties != nil {
+		add("unevaluatedProperties is ignored in favor of additionalProperties")
+	}
+
+	if t.UnevaluatedItems != nil && t.Items != nil {
+		add("unevaluatedItems is ignored in favor of items")
+	}
+
+	if t.DynamicRef != "" && t.Ref == "" {
+		add("$dynamicRef %q is resolved statically, like $ref", t.DynamicRef)
+	}
+
+	if t.If == nil && (t.Then != nil || t.Else != nil) {
+		add("then and else are ignored without if")
+	}
+
+	if t.If != nil {
+		if unchecked := uncheckedKeywords(t.If); len(unchecked) > 0 {
+			add("if uses unsupported keywords %s; the condition will not be validated",
+				strings.Join(unchecked, ", "))
+		}
+
+		for _, branch := range []struct {
+			keyword string
+			schema  *schemas.Type
+		}{
+			{"then", t.Then},
+			{"else", t.Else},
+		} {
+			if branch.schema == nil {
+				continue
+			}
+
+			if unchecked := uncheckedKeywords(branch.schema); len(unchecked) > 0 {
+				add("%s uses unsupported keywords %s; they will not be validated",
+					branch.keyword, strings.Join(unchecked, ", "))
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(t.DependentSchemas) {
+		if unchecked := uncheckedKeywords(t.DependentSchemas[name]); len(unchecked) > 0 {
+			add("dependentSchemas/%s uses unsupported keywords %s; they will not be validated",
+				escapePointerToken(name), strings.Join(unchecked, ", "))
+		}
+	}
+
+	if t.Not != nil && !isObjectNot(t.Not) && !isValueNot(t.Not) {
+		add("not uses unsupported keywords %s; it will not be validated",
+			strings.Join(keywordsNotIn(t.Not, valueCheckKeywords), ", "))
+	}
+
+	// Object and array values are validated by the unmarshaler of their Go
+	// type; other values are only checked against the raw value.
+	for _, pattern := range sortedKeys(t.PatternProperties) {
+		schema := t.PatternProperties[pattern]
+		if schema.Ref != "" || slices.Contains(schema.Type, schemas.TypeNameObject) ||
+			slices.Contains(schema.Type, schemas.TypeNameArray) {
+			continue
+		}
+
+		if unchecked := keywordsNotIn(schema, valueCheckKeywords); len(unchecked) > 0 {
+			add("patternProperties/%s uses unsupported keywords %s; they will not be validated",
+				escapePointerToken(pattern), strings.Join(unchecked, ", "))
+		}
+	}
+
+	if t.PropertyNames != nil {
+		if unchecked := keywordsNotIn(t.PropertyNames, valueCheckKeywords); len(unchecked) > 0 {
+			add("propertyNames uses unsupported keywords %s; they will not be validated", strings.Join(unchecked, ", "))
+		}
+	}
+
+	if t.Contains != nil {
+		if unchecked := keywordsNotIn(t.Contains, valueCheckKeywords); len(unchecked) > 0 {
+			add("contains uses unsupported keywords %s; they will not be validated", strings.Join(unchecked, ", "))
+		}
+	} else if t.MinContains != nil || t.MaxContains != nil {
+		add("minContains and maxContains are ignored without contains")
+	}
+
+	// The constraints of values are validated for the fields of structs and
+	// for declared types, but not for the elements of slices and maps, unless
+	// their type is declared.
+	elements := []struct {
+		keyword string
+		schema  *schemas.Type
+	}{
+		{"items", t.Items},
+		{"additionalItems", t.AdditionalItems},
+		{"additionalProperties", t.AdditionalProperties},
+	}
+
+	for i, item := range t.PrefixItems {
+		elements = append(elements, struct {
+			keyword string
+			schema  *schemas.Type
+		}{fmt.Sprintf("prefixItems/%d", i), item})
+	}
+
+	for _, element := range elements {
+		schema := element.schema
+		if schema == nil || schema.Ref != "" || schema.Enum != nil ||
+			slices.Contains(schema.Type, schemas.TypeNameObject) || len(schema.Properties) > 0 {
+			continue
+		}
+
+		if unchecked := keywordsIn(schema, elementValueKeywords); len(unchecked) > 0 {
+			add("%s uses keywords %s, which are not validated inline; declare it in $defs and $ref it to validate them",
+				element.keyword, strings.Join(unchecked, ", "))
+		}
+	}
+
+	return messages
+}
+
+// inapplicableKeywords describes the keywords of t that apply to none of its
+// types, so that the validators of its Go type ignore them.
+func inapplicableKeywords(t *schemas.Type) []string {
+	if len(t.Type) == 0 {
+		return nil
+	}
+
+	var messages []string
+
+	for _, keyword := range keywordsNotIn(t, nil) {
+		types, ok := typeKeywords[keyword]
+		if ok && !slices.ContainsFunc(t.Type, func(name string) bool { return slices.Contains(types, name) }) {
+			messages = append(messages, fmt.Sprintf("%s does not apply to type %s and is ignored",
+				keyword, strings.Join(t.Type, ", ")))
+		}
+	}
+
+	return messages
+}
+
+// mergeConditionalProperties adds the properties of subschemas that only
+// apply under some condition to the type, as optional fields.
+func mergeConditionalProperties(t *schemas.Type, subschemas []*schemas.Type, warn func(string, ...any)) {
+	merged := map[string]struct{}{}
+
+	for _, schema := range subschemas {
+		for _, name := range sortedKeys(schema.Properties) {
+			prop := conditionalPropertyType(schema.Properties[name])
+
+			existing, ok := t.Properties[name]
+			if !ok {
+				if t.Properties == nil {
+					t.Properties = map[string]*schemas.Type{}
+				}
+
+				t.Properties[name] = prop
+				merged[name] = struct{}{}
+
+				continue
+			}
+
+			// A property of several subschemas needs a type that fits all.
+			if _, ok := merged[name]; ok && !cmp.Equal(existing, prop, cmputil.Opts(*existing, *prop)...) {
+				warn("property %q has differing types in conditional subschemas; "+
+					"it will be represented as interface{}", name)
+
+				t.Properties[name] = &schemas.Type{}
+			}
+		}
+	}
+}
+
+// conditionalPropertyType returns the part of the schema of a property of a
+// conditional subschema that determines its Go type. Its constraints only apply
+// conditionally, so they are left to the conditional validator.
+func conditionalPropertyType(t *schemas.Type) *schemas.Type {
+	return &schemas.Type{
+		Ref:                   t.Ref,
+		Type:                  t.Type,
+		Format:                t.Format,
+		Items:                 t.Items,
+		Properties:            t.Properties,
+		AdditionalProperties:  t.AdditionalProperties,
+		Title:                 t.Title,
+		Description:           t.Description,
+		GoJSONSchemaExtension: t.GoJSONSchemaExtension,
+	}
+}
+
+// arrayItemsType returns the schema shared by all items of an array. With
+// prefixItems, that is only known when every position has the same schema;
+// otherwise nil is returned.
+func arrayItemsType(t *schemas.Type) *schemas.Type {
+	if len(t.PrefixItems) == 0 {
+		return t.Items
+	}
+
+	items := t.PrefixItems
+	if t.Items != nil && !t.Items.IsFalse() {
+		items = append(items[:len(items):len(items)], t.Items)
+	}
+
+	for _, item := range items[1:] {
+		if !cmp.Equal(items[0], item, cmputil.Opts(*items[0], *item)...) {
+			return nil
+		}
+	}
+
+	return items[0]
+}
+
+// constTypeName returns the JSON schema type name of a const value.
+func constTypeName(value any) string {
+	switch v := value.(type) {
+	case string:
+		return schemas.TypeNameString
+
+	case bool:
+		return schemas.TypeNameBoolean
+
+	case float64:
+		if v == math.Trunc(v) {
+			return schemas.TypeNameInteger
+		}
+
+		return schemas.TypeNameNumber
+
+	case map[string]any:
+		return schemas.TypeNameObject
+
+	case []any:
+		return schemas.TypeNameArray
+
+	default:
+		return schemas.TypeNameNull
+	}
+}
+
+// typeOrigin returns where t was written or, when that is unknown, the file
+// and pointer it was reached at.
+func typeOrigin(t *schemas.Type, fileName, pointer string) schemas.Origin {
+	if t != nil {
+		if origin := t.Origin(); origin.File != "" {
+			return origin
+		}
+	}
+
+	return schemas.Origin{File: fileName, Pointer: pointer}
+}
+
+// escapePointerToken escapes a reference token as described in RFC 6901.
+func escapePointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,368 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+// The rules of lint findings.
+const (
+	// RuleMetaSchema reports where a schema does not conform to the
+	// meta-schema of its dialect.
+	RuleMetaSchema = "meta-schema"
+	// RuleMisspelledKeyword reports unknown keywords that are close to one
+	// of the dialect of the schema.
+	RuleMisspelledKeyword = "misspelled-keyword"
+	// RuleDialect reports keywords that are not in the dialect of the schema.
+	RuleDialect = "dialect"
+	// RuleUnhonoredKeyword reports keywords that the generated code does not
+	// enforce.
+	RuleUnhonoredKeyword = "unhonored-keyword"
+	// RuleInapplicableKeyword reports keywords that do not apply to any of
+	// the types a schema allows.
+	RuleInapplicableKeyword = "inapplicable-keyword"
+	// RuleAmbiguousOneOf reports oneOf branches that cannot be told apart.
+	RuleAmbiguousOneOf = "ambiguous-oneof"
+	// RuleConflictingAllOf reports allOf members that no value can satisfy
+	// together.
+	RuleConflictingAllOf = "conflicting-allof"
+	// RuleUnusedDefinition reports definitions that are never referenced.
+	RuleUnusedDefinition = "unused-definition"
+)
+
+// Finding is a construct of a schema that is not honored by the generated
+// code or that is likely a mistake.
+type Finding struct {
+	schemas.Origin
+
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s [%s]", locatedMessage(f.Origin, f.Message), f.Rule)
+}
+
+// Lint reports where a schema does not conform to its meta-schema and the
+// constructs that the generated code does not honor: keywords it ignores or
+// only partly enforces, oneOf branches that cannot be told apart, allOf
+// members that conflict and definitions that are never referenced from
+// within the document. The fileName locates the findings whose schema has
+// no known origin. Lint does not follow $refs to other documents.
+func Lint(fileName string, schema *schemas.Schema) []Finding {
+	l := &linter{fileName: fileName, schema: schema}
+
+	misspellings, err := schema.LintMeta()
+
+	var metaErr *schemas.MetaSchemaError
+	if errors.As(err, &metaErr) {
+		l.reportViolations(metaErr.Violations, RuleMetaSchema)
+	}
+
+	l.reportViolations(misspellings, RuleMisspelledKeyword)
+
+	for _, w := range schema.Normalize() {
+		t, _ := schema.ResolvePointer(w.Pointer)
+		l.report(t, w.Pointer, RuleDialect, w.Message)
+	}
+
+	schema.Walk(func(pointer string, t *schemas.Type) {
+		for _, message := range unhonoredKeywords(t) {
+			l.report(t, pointer, RuleUnhonoredKeyword, message)
+		}
+
+		for _, message := range inapplicableKeywords(t) {
+			l.report(t, pointer, RuleInapplicableKeyword, message)
+		}
+
+		l.lintOneOf(pointer, t)
+		l.lintAllOf(pointer, t)
+	})
+
+	l.lintDefinitions()
+
+	return l.findings
+}
+
+type linter struct {
+	fileName string
+	schema   *schemas.Schema
+	findings []Finding
+}
+
+func (l *linter) report(t *schemas.Type, pointer, rule, message string) {
+	l.findings = append(l.findings, Finding{
+		Origin:  typeOrigin(t, l.fileName, pointer),
+		Rule:    rule,
+		Message: message,
+	})
+}
+
+// reportViolations reports meta-schema violations under the rule, in the
+// file being linted if they have no origin of their own.
+func (l *linter) reportViolations(violations []schemas.Violation, rule string) {
+	for _, v := range violations {
+		origin := v.Origin
+		if origin.File == "" {
+			origin.File = l.fileName
+		}
+
+		l.findings = append(l.findings, Finding{Origin: origin, Rule: rule, Message: v.Message})
+	}
+}
+
+// lintOneOf reports oneOf branches that may accept the same value, unless a
+// property with a different const or enum in each of them tells them apart.
+func (l *linter) lintOneOf(pointer string, t *schemas.Type) {
+	branches, ok := l.resolveAll(t.OneOf)
+	if !ok || len(branches) < 2 {
+		return
+	}
+
+	if discriminator(branches) != "" {
+		return
+	}
+
+	for i := range branches {
+		for j := i + 1; j < len(branches); j++ {
+			if typesOverlap(valueTypes(branches[i]), valueTypes(branches[j])) {
+				l.report(t, pointer, RuleAmbiguousOneOf, fmt.Sprintf(
+					"oneOf/%d and oneOf/%d may accept the same values, and no property with a const or enum "+
+						"tells the branches apart", i, j))
+
+				return
+			}
+		}
+	}
+}
+
+// lintAllOf reports allOf members that allow no common type, or that
+// declare the same property with types that have no value in common.
+func (l *linter) lintAllOf(pointer string, t *schemas.Type) {
+	members, ok := l.resolveAll(t.AllOf)
+	if !ok {
+		return
+	}
+
+	for i := range members {
+		for j := i + 1; j < len(members); j++ {
+			a, b := members[i], members[j]
+
+			if !typesOverlap(valueTypes(a), valueTypes(b)) {
+				l.report(t, pointer, RuleConflictingAllOf, fmt.Sprintf(
+					"allOf/%d and allOf/%d require different types", i, j))
+
+				continue
+			}
+
+			for _, name := range sortedKeys(a.Properties) {
+				other, ok := b.Properties[name]
+				if !ok {
+					continue
+				}
+
+				propA, okA := l.resolve(a.Properties[name])
+				propB, okB := l.resolve(other)
+
+				if okA && okB && !typesOverlap(valueTypes(propA), valueTypes(propB)) {
+					l.report(t, pointer, RuleConflictingAllOf, fmt.Sprintf(
+						"allOf/%d and allOf/%d declare property %q with different types", i, j, name))
+				}
+			}
+		}
+	}
+}
+
+// lintDefinitions reports the definitions that no $ref of the document
+// points into. Definitions with an $id may be referenced from elsewhere.
+func (l *linter) lintDefinitions() {
+	var refs []string
+
+	l.schema.Walk(func(_ string, t *schemas.Type) {
+		for _, ref := range []string{t.Ref, t.DynamicRef} {
+			if target, ok := l.refPointer(ref); ok {
+				refs = append(refs, target)
+			}
+		}
+	})
+
+	l.schema.Walk(func(pointer string, t *schemas.Type) {
+		for _, name := range sortedKeys(t.Definitions) {
+			l.lintDefinition(pointer+"/$defs/"+escapePointerToken(name), t.Definitions[name], refs)
+		}
+	})
+
+	for _, name := range sortedKeys(l.schema.Definitions) {
+		l.lintDefinition("/$defs/"+escapePointerToken(name), l.schema.Definitions[name], refs)
+	}
+}
+
+func (l *linter) lintDefinition(pointer string, def *schemas.Type, refs []string) {
+	if def.ID != "" {
+		return
+	}
+
+	for _, ref := range refs {
+		if ref == pointer || strings.HasPrefix(ref, pointer+"/") {
+			return
+		}
+	}
+
+	l.report(def, pointer, RuleUnusedDefinition, "definition is never referenced")
+}
+
+// refPointer returns the JSON pointer a $ref within the document points to.
+func (l *linter) refPointer(ref string) (string, bool) {
+	fragment, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return "", false
+	}
+
+	if fragment == "" || strings.HasPrefix(fragment, "/") {
+		tokens, err := schemas.ParsePointer(fragment)
+		if err != nil {
+			return "", false
+		}
+
+		for i, token := range tokens {
+			tokens[i] = escapePointerToken(token)
+			if token == "definitions" {
+				tokens[i] = "$defs"
+			}
+		}
+
+		if len(tokens) == 0 {
+			return "", true
+		}
+
+		return "/" + strings.Join(tokens, "/"), true
+	}
+
+	defName, _, ok := l.schema.FindAnchor(fragment)
+	if !ok || defName == "" {
+		return "", ok
+	}
+
+	return "/$defs/" + escapePointerToken(defName), true
+}
+
+// resolve follows the $ref of t within the document; refs to other
+// documents are not resolved.
+func (l *linter) resolve(t *schemas.Type) (*schemas.Type, bool) {
+	if t.Ref == "" {
+		return t, true
+	}
+
+	if strings.HasPrefix(t.Ref, "#") && !strings.HasPrefix(t.Ref, "#/") && t.Ref != "#" {
+		_, def, ok := l.schema.FindAnchor(t.Ref[1:])
+
+		return def, ok
+	}
+
+	pointer, ok := l.refPointer(t.Ref)
+	if !ok {
+		return nil, false
+	}
+
+	def, err := l.schema.ResolvePointer(pointer)
+
+	return def, err == nil
+}
+
+func (l *linter) resolveAll(types []*schemas.Type) ([]*schemas.Type, bool) {
+	resolved := make([]*schemas.Type, 0, len(types))
+
+	for _, t := range types {
+		r, ok := l.resolve(t)
+		if !ok {
+			return nil, false
+		}
+
+		resolved = append(resolved, r)
+	}
+
+	return resolved, true
+}
+
+// discriminator returns a property that every branch requires to be one of
+// a few values, which differ between the branches.
+func discriminator(branches []*schemas.Type) string {
+	for _, name := range sortedKeys(branches[0].Properties) {
+		seen := map[string]struct{}{}
+		distinct := true
+
+		for _, branch := range branches {
+			prop, ok := branch.Properties[name]
+			if !ok {
+				distinct = false
+
+				break
+			}
+
+			values := prop.Enum
+			if prop.Const != nil {
+				values = []any{*prop.Const}
+			}
+
+			if len(values) == 0 {
+				distinct = false
+
+				break
+			}
+
+			for _, value := range values {
+				key := fmt.Sprint(value)
+				if _, ok := seen[key]; ok {
+					distinct = false
+				}
+
+				seen[key] = struct{}{}
+			}
+		}
+
+		if distinct {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// valueTypes returns the types of the values a schema allows, or nil if it
+// does not restrict them.
+func valueTypes(t *schemas.Type) []string {
+	switch {
+	case len(t.Type) > 0:
+		return t.Type
+
+	case t.Const != nil:
+		return []string{constTypeName(*t.Const)}
+
+	case len(t.Properties) > 0:
+		return []string{schemas.TypeNameObject}
+
+	default:
+		return nil
+	}
+}
+
+func typesOverlap(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+
+	for _, x := range a {
+		for _, y := range b {
+			if x == y ||
+				x == schemas.TypeNameInteger && y == schemas.TypeNameNumber ||
+				x == schemas.TypeNameNumber && y == schemas.TypeNameInteger {
+				return true
+			}
+		}
+	}
+
+	return false
+}
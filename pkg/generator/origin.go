@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+// SchemaError is an error in a schema, located where the failing subschema
+// was written.
+type SchemaError struct {
+	Origin schemas.Origin
+	// Refs are the $refs that were followed to reach the subschema, the first
+	// one first.
+	Refs []RefStep
+	Err  error
+}
+
+// RefStep is a $ref that was followed, and where it was written.
+type RefStep struct {
+	Ref    string
+	Origin schemas.Origin
+}
+
+func (e *SchemaError) Error() string {
+	if len(e.Refs) == 0 {
+		return fmt.Sprintf("%s: %v", e.Origin, e.Err)
+	}
+
+	steps := make([]string, 0, len(e.Refs))
+	for _, step := range e.Refs {
+		steps = append(steps, fmt.Sprintf("$ref %q at %s", step.Ref, step.Origin))
+	}
+
+	return fmt.Sprintf("%s: %v (reached through %s)", e.Origin, e.Err, strings.Join(steps, ", "))
+}
+
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// origin returns where t was written. The types that the generator derives
+// from others are only known to be in the schema file.
+func (g *schemaGenerator) origin(t *schemas.Type) schemas.Origin {
+	if origin := t.Origin(); origin.File != "" {
+		return origin
+	}
+
+	return schemas.Origin{File: g.schemaFileName}
+}
+
+// located attaches the origin of t to err, unless it is located already or
+// t was derived by the generator, in which case a caller with a type that
+// was written in the schema locates it.
+func (g *schemaGenerator) located(t *schemas.Type, err error) error {
+	if err == nil || isLocated(err) || t.Origin().File == "" {
+		return err
+	}
+
+	return &SchemaError{Origin: t.Origin(), Err: err}
+}
+
+// isLocated reports whether err already names where it happened, in which
+// case wrapping it with more context would hide the refs that are prepended
+// to it later.
+func isLocated(err error) bool {
+	var schemaErr *SchemaError
+
+	return errors.As(err, &schemaErr)
+}
+
+// throughRef records that an error was reached by following the $ref of t.
+func (g *schemaGenerator) throughRef(t *schemas.Type, err error) error {
+	var schemaErr *SchemaError
+	if err == nil || !errors.As(err, &schemaErr) {
+		return g.located(t, err)
+	}
+
+	schemaErr.Refs = append([]RefStep{{Ref: t.Ref, Origin: g.origin(t)}}, schemaErr.Refs...)
+
+	return err
+}
+
+func (g *schemaGenerator) warn(t *schemas.Type, format string, args ...any) {
+	g.warner(locatedMessage(g.origin(t), fmt.Sprintf(format, args...)))
+}
+
+// locatedMessage prefixes a message with where it applies, when that is
+// known.
+func locatedMessage(origin schemas.Origin, message string) string {
+	if origin.File == "" {
+		return message
+	}
+
+	return fmt.Sprintf("%s: %s", origin, message)
+}
@@ -14,13 +14,16 @@ type output struct {
 	file          *codegen.File
 	declsByName   map[string]*codegen.TypeDecl
 	declsBySchema map[*schemas.Type]*codegen.TypeDecl
+	// valueNames are the names of the constants declared besides types.
+	valueNames    map[string]struct{}
+	patternFields map[*schemas.Type][]patternField
 	warner        func(string)
 }
 
-func (o *output) getDeclByEqualSchema(name string, t *schemas.Type) *codegen.TypeDecl {
+func (o *output) getDeclByEqualSchema(name string, t *schemas.Type, origin schemas.Origin) *codegen.TypeDecl {
 	v, ok := o.declsByName[name]
 	if !ok {
-		o.warner(fmt.Sprintf("Name not found: %s", name))
+		o.warner(locatedMessage(origin, fmt.Sprintf("Name not found: %s", name)))
 
 		return nil
 	}
@@ -49,10 +52,11 @@ func (o *output) isUniqueTypeName(name string) bool {
 	return !ok || (ok && v.Type == nil)
 }
 
-func (o *output) uniqueTypeName(name string) string {
+func (o *output) uniqueTypeName(name string, origin schemas.Origin) string {
 	v, ok := o.declsByName[name]
+	_, isValue := o.valueNames[name]
 
-	if !ok || (ok && v.Type == nil) {
+	if !isValue && (!ok || (ok && v.Type == nil)) {
 		return name
 	}
 
@@ -60,9 +64,9 @@ func (o *output) uniqueTypeName(name string) string {
 
 	for {
 		suffixed := fmt.Sprintf("%s_%d", name, count)
-		if _, ok := o.declsByName[suffixed]; !ok {
-			o.warner(fmt.Sprintf(
-				"Multiple types map to the name %q; declaring duplicate as %q instead", name, suffixed))
+		if !o.isTakenName(suffixed) {
+			o.warner(locatedMessage(origin, fmt.Sprintf(
+				"Multiple types map to the name %q; declaring duplicate as %q instead", name, suffixed)))
 
 			return suffixed
 		}
@@ -70,3 +74,29 @@ func (o *output) uniqueTypeName(name string) string {
 		count++
 	}
 }
+
+// uniqueValueName returns a name for a constant that no type or other
+// constant has, and reserves it.
+func (o *output) uniqueValueName(name string, origin schemas.Origin) string {
+	unique := name
+
+	for count := 1; o.isTakenName(unique); count++ {
+		unique = fmt.Sprintf("%s_%d", name, count)
+	}
+
+	if unique != name {
+		o.warner(locatedMessage(origin, fmt.Sprintf(
+			"Multiple values map to the name %q; declaring duplicate as %q instead", name, unique)))
+	}
+
+	o.valueNames[unique] = struct{}{}
+
+	return unique
+}
+
+func (o *output) isTakenName(name string) bool {
+	_, isType := o.declsByName[name]
+	_, isValue := o.valueNames[name]
+
+	return isType || isValue
+}
@@ -0,0 +1,341 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/walteh/schema2go/pkg/cmputil"
+	"github.com/walteh/schema2go/pkg/codegen"
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+// patternField is a struct field that holds the properties whose names match
+// a pattern of patternProperties.
+type patternField struct {
+	pattern   string
+	fieldName string
+}
+
+// patternMapValueType tells whether an object without declared properties can
+// be represented as a single map, and returns the schema of its values. That
+// is the case when every property is described by the same schema; a nil
+// schema means the values can be anything.
+func patternMapValueType(t *schemas.Type) (*schemas.Type, bool) {
+	if len(t.PatternProperties) == 0 {
+		return t.AdditionalProperties, true
+	}
+
+	patterns := sortedKeys(t.PatternProperties)
+	value := t.PatternProperties[patterns[0]]
+
+	for _, pattern := range patterns[1:] {
+		if !equalSchemas(value, t.PatternProperties[pattern]) {
+			return nil, false
+		}
+	}
+
+	switch {
+	case t.AdditionalProperties == nil:
+		// Properties that match no pattern can still have any value.
+		return nil, true
+
+	case t.AdditionalProperties.IsFalse(), equalSchemas(value, t.AdditionalProperties):
+		return value, true
+
+	default:
+		return nil, false
+	}
+}
+
+func equalSchemas(a, b *schemas.Type) bool {
+	return cmp.Equal(a, b, cmputil.Opts(*a, *b)...)
+}
+
+// addPatternFields adds a map field to the struct for every pattern of
+// patternProperties.
+func (g *schemaGenerator) addPatternFields(
+	structType *codegen.StructType,
+	t *schemas.Type,
+	scope nameScope,
+	uniqueNames map[string]int,
+) error {
+	patterns := sortedKeys(t.PatternProperties)
+	fields := make([]patternField, 0, len(patterns))
+
+	for i, pattern := range patterns {
+		fieldName := "PatternProperties"
+		if len(patterns) > 1 {
+			fieldName = patternFieldName(g.caser.Identifierize(identifierChars(pattern)), i)
+		}
+
+		if count, ok := uniqueNames[fieldName]; ok {
+			uniqueNames[fieldName] = count + 1
+			fieldName = fmt.Sprintf("%s_%d", fieldName, count+1)
+		} else {
+			uniqueNames[fieldName] = 1
+		}
+
+		valueType, err := g.generateTypeInline(t.PatternProperties[pattern], scope.add(fieldName).add("Value"))
+		if err != nil {
+			return fmt.Errorf("could not generate type for pattern %q: %w", pattern, err)
+		}
+
+		tags := make([]string, 0, len(g.config.Tags))
+		for _, tag := range g.config.Tags {
+			tags = append(tags, tag+`:"-"`)
+		}
+
+		structType.AddField(codegen.StructField{
+			Name:       fieldName,
+			Comment:    fmt.Sprintf("%s holds the properties whose names match %q.", fieldName, pattern),
+			Tags:       strings.Join(tags, " "),
+			SchemaType: &schemas.Type{},
+			Type: &codegen.MapType{
+				KeyType:   codegen.PrimitiveType{Type: schemas.TypeNameString},
+				ValueType: valueType,
+			},
+		})
+
+		fields = append(fields, patternField{pattern: pattern, fieldName: fieldName})
+	}
+
+	g.output.patternFields[t] = fields
+
+	return nil
+}
+
+// identifierChars replaces the characters of a pattern that cannot be part of
+// an identifier with separators.
+func identifierChars(pattern string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+
+		return '_'
+	}, pattern)
+}
+
+func patternFieldName(identifier string, index int) string {
+	if identifier == "Undefined" {
+		return fmt.Sprintf("PatternProperties%d", index+1)
+	}
+
+	return identifier + "Properties"
+}
+
+// emitAddPatternProperties decodes the marshaled plain value in b into a map
+// of properties, and adds the properties of the pattern fields to it.
+func emitAddPatternProperties(out *codegen.Emitter, format string, patternFields []patternField) {
+	out.Printlnf("properties := map[string]interface{}{}")
+	out.Printlnf("if err := %s.Unmarshal(b, &properties); err != nil { return nil, err }", format)
+
+	for _, f := range patternFields {
+		out.Printlnf("for key, v := range j.%s {", f.fieldName)
+		out.Indent(1)
+		out.Printlnf("properties[key] = v")
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+}
+
+// patternPropertiesValidators returns the validators that check the
+// properties of an object against patternProperties and, for a struct,
+// decode them into the pattern fields.
+func (g *schemaGenerator) patternPropertiesValidators(declName string, t *schemas.Type) []validator {
+	if len(t.PatternProperties) == 0 {
+		return nil
+	}
+
+	var validators []validator
+
+	v := &patternPropertiesValidator{
+		declName:     declName,
+		declared:     sortedKeys(t.Properties),
+		onlyPatterns: t.AdditionalProperties.IsFalse(),
+	}
+
+	for _, pattern := range sortedKeys(t.PatternProperties) {
+		checks := valueChecks("value", t.PatternProperties[pattern])
+
+		for _, check := range checks {
+			for _, pkg := range check.imports {
+				g.output.file.Package.AddImport(pkg, "")
+			}
+		}
+
+		v.patterns = append(v.patterns, pattern)
+		v.checks = append(v.checks, checks)
+	}
+
+	if v.onlyPatterns || v.hasChecks() {
+		if v.onlyPatterns && len(v.declared) > 0 {
+			g.output.file.Package.AddImport("slices", "")
+		}
+
+		validators = append(validators, v)
+	}
+
+	if fields := g.output.patternFields[t]; len(fields) > 0 {
+		validators = append(validators, &patternFieldsValidator{
+			fields:     fields,
+			additional: t.AdditionalProperties != nil && !t.AdditionalProperties.IsFalse(),
+		})
+	}
+
+	if len(validators) > 0 {
+		g.output.file.Package.AddImport("regexp", "")
+	}
+
+	return validators
+}
+
+// patternPropertiesValidator checks every property whose name matches a
+// pattern against its schema, and rejects the properties that neither match
+// a pattern nor are declared when additionalProperties is false.
+type patternPropertiesValidator struct {
+	declName     string
+	declared     []string
+	onlyPatterns bool
+	patterns     []string
+	// checks holds the checks of each pattern.
+	checks [][]schemaCheck
+}
+
+func (v *patternPropertiesValidator) hasChecks() bool {
+	for _, checks := range v.checks {
+		if len(checks) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v *patternPropertiesValidator) generate(out *codegen.Emitter, format string) {
+	if v.hasChecks() {
+		out.Printlnf("for key, v := range %s {", varNameRawMap)
+	} else {
+		out.Printlnf("for key := range %s {", varNameRawMap)
+	}
+
+	out.Indent(1)
+
+	if v.onlyPatterns {
+		out.Printlnf("matched := false")
+	}
+
+	for i, pattern := range v.patterns {
+		if !v.onlyPatterns && len(v.checks[i]) == 0 {
+			continue
+		}
+
+		out.Printlnf("if ok, _ := regexp.MatchString(`%s`, key); ok {", pattern)
+		out.Indent(1)
+
+		if v.onlyPatterns {
+			out.Printlnf("matched = true")
+		}
+
+		for _, check := range v.checks[i] {
+			check.emit(out, "v", func() {
+				out.Printlnf(`return fmt.Errorf("property %%q in %s: %%s", key, %s)`,
+					v.declName, strconv.Quote(check.violation))
+			})
+		}
+
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	if v.onlyPatterns {
+		if len(v.declared) > 0 {
+			out.Printlnf("if !matched && !slices.Contains(%#v, key) {", v.declared)
+		} else {
+			out.Printlnf("if !matched {")
+		}
+
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("property %%q in %s: not allowed", key)`, v.declName)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	out.Indent(-1)
+	out.Printlnf("}")
+}
+
+func (v *patternPropertiesValidator) desc() *validatorDesc {
+	return &validatorDesc{
+		hasError:            true,
+		beforeJSONUnmarshal: true,
+	}
+}
+
+// patternFieldsValidator decodes the properties whose names match a pattern
+// into the field of that pattern, so that they are unmarshaled like any other
+// value of its type.
+type patternFieldsValidator struct {
+	fields []patternField
+	// additional tells that the remaining properties are decoded into the
+	// additional properties, which must not include the matched ones.
+	additional bool
+}
+
+func (v *patternFieldsValidator) generate(out *codegen.Emitter, format string) {
+	for i := range v.fields {
+		out.Printlnf("patternValues%d := map[string]interface{}{}", i)
+	}
+
+	out.Printlnf("for key, v := range %s {", varNameRawMap)
+	out.Indent(1)
+
+	if v.additional {
+		out.Printlnf("matched := false")
+	}
+
+	for i, f := range v.fields {
+		out.Printlnf("if ok, _ := regexp.MatchString(`%s`, key); ok {", f.pattern)
+		out.Indent(1)
+		out.Printlnf("patternValues%d[key] = v", i)
+
+		if v.additional {
+			out.Printlnf("matched = true")
+		}
+
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	if v.additional {
+		out.Printlnf("if matched {")
+		out.Indent(1)
+		out.Printlnf("delete(%s, key)", varNameRawMap)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	out.Indent(-1)
+	out.Printlnf("}")
+
+	for i, f := range v.fields {
+		out.Printlnf("if len(patternValues%d) > 0 {", i)
+		out.Indent(1)
+		out.Printlnf("b, err := %s.Marshal(patternValues%d)", format, i)
+		out.Printlnf("if err != nil { return err }")
+		out.Printlnf("if err := %s.Unmarshal(b, &%s); err != nil { return err }", format, getPlainName(f.fieldName))
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+}
+
+func (v *patternFieldsValidator) desc() *validatorDesc {
+	return &validatorDesc{
+		hasError:         false,
+		requiresRawAfter: true,
+	}
+}
@@ -1,9 +1,14 @@
 package generator
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"net/url"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -85,7 +90,11 @@ func (g *schemaGenerator) generateRootType() error {
 	return err
 }
 
-func (g *schemaGenerator) generateReferencedType(t *schemas.Type) (codegen.Type, error) {
+func (g *schemaGenerator) generateReferencedType(t *schemas.Type) (_ codegen.Type, err error) {
+	defer func() {
+		err = g.throughRef(t, err)
+	}()
+
 	if schemaOutput, ok := g.outputs[g.schema.ID]; ok {
 		if decl, ok := schemaOutput.declsByName[t.Ref]; ok {
 			if decl != nil {
@@ -106,7 +115,7 @@ func (g *schemaGenerator) generateReferencedType(t *schemas.Type) (codegen.Type,
 		return codegen.EmptyInterfaceType{}, nil
 	}
 
-	defName, fileName, err := g.extractRefNames(t)
+	pointer, fileName, anchor, err := g.extractRefNames(t)
 	if err != nil {
 		return nil, err
 	}
@@ -114,10 +123,36 @@ func (g *schemaGenerator) generateReferencedType(t *schemas.Type) (codegen.Type,
 	schema := g.schema
 	sg := g
 
-	if fileName != "" {
+	if res, ok := g.findResource(fileName); ok {
+		// The resource may be embedded in a document loaded from elsewhere, so
+		// it is only found by its $id.
+		if res.schema != g.schema {
+			output, oerr := g.findOutputFileForSchemaID(res.schema.ID)
+			if oerr != nil {
+				return nil, oerr
+			}
+
+			schema = res.schema
+			sg = newSchemaGenerator(g.Generator, schema, res.fileName, output)
+		}
+
+		if anchor != "" {
+			anchorPointer, ok := res.FindAnchor(anchor)
+			if !ok {
+				return nil, fmt.Errorf("%w: anchor %q (from ref %q)", errDefinitionDoesNotExistInSchema, anchor, t.Ref)
+			}
+
+			pointer, anchor = anchorPointer, ""
+		}
+
+		pointer = res.Pointer + pointer
+		fileName = res.fileName
+	} else if fileName != "" {
 		var serr error
 
-		schema, serr = g.loader.Load(fileName, g.schemaFileName)
+		fileName = g.resolveRemoteRef(fileName)
+
+		schema, serr = g.load(fileName, g.schemaFileName)
 		if serr != nil {
 			return nil, fmt.Errorf("could not follow $ref %q to file %q: %w", t.Ref, fileName, serr)
 		}
@@ -136,25 +171,37 @@ func (g *schemaGenerator) generateReferencedType(t *schemas.Type) (codegen.Type,
 			return nil, oerr
 		}
 
-		sg = newSchemaGenerator(g.Generator, schema, fileName, output)
+		sg = newSchemaGenerator(g.Generator, schema, qualified, output)
 	}
 
-	var def *schemas.Type
+	if anchor != "" {
+		defName, _, ok := schema.FindAnchor(anchor)
+		if !ok {
+			return nil, fmt.Errorf("%w: anchor %q (from ref %q)", errDefinitionDoesNotExistInSchema, anchor, t.Ref)
+		}
 
-	if defName != "" {
-		// TODO: Support nested definitions.
-		var ok bool
+		if defName != "" {
+			pointer = "/$defs/" + escapePointerToken(defName)
+		}
+	}
 
-		def, ok = schema.Definitions[defName]
-		if !ok {
-			return nil, fmt.Errorf("%w: %q (from ref %q)", errDefinitionDoesNotExistInSchema, defName, t.Ref)
+	var (
+		def     *schemas.Type
+		defName string
+	)
+
+	if pointer != "" {
+		if def, err = schema.ResolvePointer(pointer); err != nil {
+			return nil, fmt.Errorf("%w: %w (from ref %q)", errDefinitionDoesNotExistInSchema, err, t.Ref)
 		}
 
 		if len(def.Type) == 0 && len(def.Properties) == 0 {
 			return &codegen.EmptyInterfaceType{}, nil
 		}
 
-		defName = g.caser.Identifierize(defName)
+		// The pointer was just parsed successfully.
+		tokens, _ := schemas.ParsePointer(pointer)
+		defName = g.pointerTypeName(tokens)
 	} else {
 		def = (*schemas.Type)(schema.ObjectAsType)
 		defName = g.getRootTypeName(schema, fileName)
@@ -183,7 +230,7 @@ func (g *schemaGenerator) generateReferencedType(t *schemas.Type) (codegen.Type,
 	}
 
 	if isCycle {
-		g.warner(fmt.Sprintf("Cycle detected; must wrap type %s in pointer", nt.Decl.Name))
+		g.warn(t, "Cycle detected; must wrap type %s in pointer", nt.Decl.Name)
 
 		dt = codegen.WrapTypeInPointer(dt)
 	}
@@ -212,43 +259,125 @@ func (g *schemaGenerator) generateReferencedType(t *schemas.Type) (codegen.Type,
 	}, nil
 }
 
-func (g *schemaGenerator) extractRefNames(t *schemas.Type) (string, string, error) {
-	scope := ""
-	defName := ""
-	fileName := t.Ref
+// findResource returns the resource that a $ref to the given URI refers to,
+// resolving the URI against the $id of the schema.
+func (g *schemaGenerator) findResource(uri string) (resource, bool) {
+	if uri == "" {
+		return resource{}, false
+	}
 
-	if i := strings.IndexRune(t.Ref, '#'); i != -1 {
-		var prefix string
+	ref, err := url.Parse(uri)
+	if err != nil {
+		return resource{}, false
+	}
 
-		fileName, scope = t.Ref[0:i], t.Ref[i+1:]
-		lowercaseScope := strings.ToLower(scope)
+	if !ref.IsAbs() {
+		base, err := url.Parse(g.schema.ID)
+		if err != nil || !base.IsAbs() {
+			return resource{}, false
+		}
 
-		for _, currentPrefix := range []string{
-			"/$defs/",       // Draft-handrews-json-schema-validation-02.
-			"/definitions/", // Legacy.
-		} {
-			if strings.HasPrefix(lowercaseScope, currentPrefix) {
-				prefix = currentPrefix
+		ref = base.ResolveReference(ref)
+	}
 
-				break
+	key, err := schemas.ResourceURI(ref.String())
+	if err != nil {
+		return resource{}, false
+	}
+
+	res, ok := g.resources[key]
+
+	return res, ok
+}
+
+// resolveRemoteRef resolves a relative file name against the URL the current
+// document was loaded from, if any, so that documents served over HTTP or
+// from a mirror can refer to their neighbours.
+func (g *schemaGenerator) resolveRemoteRef(fileName string) string {
+	base, ok := g.remoteURIs[g.schema]
+	if !ok {
+		return fileName
+	}
+
+	ref, err := url.Parse(fileName)
+	if err != nil || ref.IsAbs() {
+		return fileName
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return fileName
+	}
+
+	return baseURL.ResolveReference(ref).String()
+}
+
+// extractRefNames splits a $ref into the file it points to and either a JSON
+// pointer within that file or a plain-name fragment ($anchor).
+func (g *schemaGenerator) extractRefNames(t *schemas.Type) (string, string, string, error) {
+	fileName, fragment, _ := strings.Cut(t.Ref, "#")
+
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		return "", fileName, fragment, nil
+	}
+
+	// Fragments are URI-encoded on top of the escaping of JSON pointers.
+	pointer, err := url.PathUnescape(fragment)
+	if err != nil {
+		return "", "", "", fmt.Errorf("%w: invalid fragment in %q: %w", errCannotGenerateReferencedType, t.Ref, err)
+	}
+
+	return pointer, fileName, "", nil
+}
+
+// pointerTypeName derives the name of the type declared for the subschema a
+// JSON pointer refers to from its path: the names of definitions and
+// properties, and the other keywords along the way. For instance, both
+// /$defs/a/$defs/b and /properties/a/properties/b give AB, and
+// /properties/spec/items gives SpecItems.
+func (g *schemaGenerator) pointerTypeName(tokens []string) string {
+	var name strings.Builder
+
+	for i := 0; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "$defs", "definitions", "properties", "patternProperties", "dependentSchemas":
+			if i+1 < len(tokens) {
+				i++
+				name.WriteString(g.caser.Identifierize(tokens[i]))
 			}
-		}
 
-		if len(prefix) == 0 {
-			return "", "", fmt.Errorf(
-				"%w: value must point to definition within file: '%s'",
-				errCannotGenerateReferencedType,
-				t.Ref,
-			)
-		}
+		case "allOf", "anyOf", "oneOf", "prefixItems":
+			name.WriteString(g.caser.Identifierize(tokens[i]))
+
+			if i+1 < len(tokens) {
+				i++
+				name.WriteString(tokens[i])
+			}
+
+		case "items":
+			name.WriteString(g.caser.Identifierize(tokens[i]))
 
-		defName = scope[len(prefix):]
+			// Before draft 2020-12, items may be an array of tuple items.
+			if i+1 < len(tokens) {
+				if _, err := strconv.Atoi(tokens[i+1]); err == nil {
+					i++
+					name.WriteString(tokens[i])
+				}
+			}
+
+		default:
+			name.WriteString(g.caser.Identifierize(tokens[i]))
+		}
 	}
 
-	return defName, fileName, nil
+	return name.String()
 }
 
-func (g *schemaGenerator) generateDeclaredType(t *schemas.Type, scope nameScope) (codegen.Type, error) {
+func (g *schemaGenerator) generateDeclaredType(t *schemas.Type, scope nameScope) (_ codegen.Type, err error) {
+	defer func() {
+		err = g.located(t, err)
+	}()
+
 	if decl, ok := g.output.declsBySchema[t]; ok {
 		if t.Dereferenced {
 			if decl.Name != scope.string() {
@@ -275,7 +404,7 @@ func (g *schemaGenerator) generateDeclaredType(t *schemas.Type, scope nameScope)
 			return &codegen.NamedType{Decl: odecl}, nil
 		}
 
-		if odecl := g.output.getDeclByEqualSchema(scope.string(), t); odecl != nil {
+		if odecl := g.output.getDeclByEqualSchema(scope.string(), t, g.origin(t)); odecl != nil {
 			return &codegen.NamedType{Decl: odecl}, nil
 		}
 	}
@@ -285,7 +414,7 @@ func (g *schemaGenerator) generateDeclaredType(t *schemas.Type, scope nameScope)
 	}
 
 	decl := codegen.TypeDecl{
-		Name:       g.output.uniqueTypeName(scope.string()),
+		Name:       g.output.uniqueTypeName(scope.string(), g.origin(t)),
 		Comment:    t.Description,
 		SchemaType: t,
 	}
@@ -300,7 +429,14 @@ func (g *schemaGenerator) generateDeclaredType(t *schemas.Type, scope nameScope)
 	if isNamedType(theType) {
 		// Don't declare named types under a new name.
 		delete(g.output.declsBySchema, t)
-		delete(g.output.declsByName, decl.Name)
+
+		if g.output.declsByName[decl.Name] == &decl {
+			delete(g.output.declsByName, decl.Name)
+		} else if nt, ok := theType.(*codegen.NamedType); ok && nt.Decl.Name == decl.Name {
+			// The type was declared under this very name for a schema derived
+			// from this one, such as the merge of an allOf; reuse it next time.
+			g.output.declsBySchema[t] = nt.Decl
+		}
 
 		return theType, nil
 	}
@@ -309,6 +445,8 @@ func (g *schemaGenerator) generateDeclaredType(t *schemas.Type, scope nameScope)
 
 	g.output.file.Package.AddDecl(&decl)
 
+	constFields := g.addConstants(&decl)
+
 	if g.config.OnlyModels {
 		return &codegen.NamedType{Decl: &decl}, nil
 	}
@@ -329,6 +467,9 @@ func (g *schemaGenerator) generateDeclaredType(t *schemas.Type, scope nameScope)
 			validators = append(validators, &requiredValidator{f, decl.Name})
 		}
 
+		validators = append(validators, g.objectValidators(decl.Name, t)...)
+		validators = append(validators, g.patternPropertiesValidators(decl.Name, t)...)
+
 		for _, f := range tt.Fields {
 			if f.DefaultValue != nil {
 				if f.Name == additionalProperties {
@@ -346,25 +487,73 @@ func (g *schemaGenerator) generateDeclaredType(t *schemas.Type, scope nameScope)
 			}
 
 			validators = g.structFieldValidators(validators, f, f.Type, false)
+
+			if f.SchemaType != nil && f.SchemaType.Contains != nil {
+				validators = append(validators, g.containsValidator(f.JSONName, f.SchemaType))
+			}
+
+			if f.SchemaType != nil && f.SchemaType.Const != nil {
+				v := newConstValidator(f)
+				if v.literal == "" {
+					g.output.file.Package.AddImport("encoding/json", "")
+				}
+
+				validators = append(validators, v)
+			}
 		}
 
+		validators = append(validators, g.declaredConstValidators(decl.Name, t, tt, varNameRawMap)...)
+		validators = append(validators, g.notValidators(decl.Name, t)...)
+		validators = append(validators, g.dependentValidators(t)...)
+		validators = append(validators, g.conditionalValidators(t)...)
+
 		if t.IsSubSchemaTypeElem() || len(validators) > 0 {
 			g.generateUnmarshaler(decl, validators)
 		}
 
+		if patternFields := g.output.patternFields[t]; len(constFields) > 0 || len(patternFields) > 0 {
+			g.generateMarshaler(decl, constFields, patternFields)
+		}
+
 	case codegen.PrimitiveType, *codegen.PrimitiveType:
 		validators = g.structFieldValidators(nil, codegen.StructField{
 			Type:       tt,
 			SchemaType: t,
 		}, tt, false)
 
+		validators = append(validators, g.declaredConstValidators(decl.Name, t, tt, varNamePlainStruct)...)
+
+		if isValueNot(t.Not) {
+			pt, ok := tt.(codegen.PrimitiveType)
+			if p, isPointer := tt.(*codegen.PrimitiveType); isPointer {
+				pt, ok = *p, true
+			}
+
+			if ok {
+				value := fmt.Sprintf("%s(%s)", pt.Type, varNamePlainStruct)
+				validators = append(validators, g.valueNotValidator(decl.Name, value, t.Not))
+			}
+		}
+
 		if t.IsSubSchemaTypeElem() || len(validators) > 0 {
 			g.generateUnmarshaler(decl, validators)
 		}
 
 	case codegen.MapType, *codegen.MapType:
-		if t.IsSubSchemaTypeElem() {
-			g.generateUnmarshaler(decl, []validator{})
+		validators = g.objectValidators(decl.Name, t)
+		validators = append(validators, g.patternPropertiesValidators(decl.Name, t)...)
+		validators = append(validators, g.declaredConstValidators(decl.Name, t, tt, varNameRawMap)...)
+		validators = append(validators, g.notValidators(decl.Name, t)...)
+
+		if t.IsSubSchemaTypeElem() || len(validators) > 0 {
+			g.generateUnmarshaler(decl, validators)
+		}
+
+	case codegen.ArrayType, *codegen.ArrayType:
+		validators = g.arrayValueValidators(decl.Name, t, tt)
+
+		if t.IsSubSchemaTypeElem() || len(validators) > 0 {
+			g.generateUnmarshaler(decl, validators)
 		}
 	}
 
@@ -440,14 +629,23 @@ func (g *schemaGenerator) structFieldValidators(
 				})
 
 				break
-			} else if f.SchemaType.MinItems != 0 || f.SchemaType.MaxItems != 0 {
+			}
+
+			uniqueItems := arrayDepth == 1 && f.SchemaType.UniqueItems
+
+			if f.SchemaType.MinItems != 0 || f.SchemaType.MaxItems != 0 || uniqueItems {
 				validators = append(validators, &arrayValidator{
-					fieldName:  f.Name,
-					jsonName:   f.JSONName,
-					arrayDepth: arrayDepth,
-					minItems:   f.SchemaType.MinItems,
-					maxItems:   f.SchemaType.MaxItems,
+					fieldName:   f.Name,
+					jsonName:    f.JSONName,
+					arrayDepth:  arrayDepth,
+					minItems:    f.SchemaType.MinItems,
+					maxItems:    f.SchemaType.MaxItems,
+					uniqueItems: uniqueItems,
 				})
+
+				if uniqueItems {
+					g.output.file.Package.AddImport("encoding/json", "")
+				}
 			}
 
 			t = v.Type
@@ -457,6 +655,101 @@ func (g *schemaGenerator) structFieldValidators(
 	return validators
 }
 
+// arrayValueValidators returns the validators of a declared array type: those
+// of its length and unique items, as for a field, and those that check its
+// value as a whole, as decoded into a generic value.
+func (g *schemaGenerator) arrayValueValidators(declName string, t *schemas.Type, tt codegen.Type) []validator {
+	var arrayType *codegen.ArrayType
+
+	switch a := tt.(type) {
+	case *codegen.ArrayType:
+		arrayType = a
+
+	case codegen.ArrayType:
+		arrayType = &a
+	}
+
+	validators := g.structFieldValidators(nil, codegen.StructField{
+		JSONName:   declName,
+		Type:       arrayType,
+		SchemaType: t,
+	}, arrayType, false)
+
+	valueValidators := g.declaredConstValidators(declName, t, arrayType, varNamePlainValue)
+
+	if t.Contains != nil {
+		v := g.containsValidator(declName, t)
+		v.value = varNamePlainValue
+		valueValidators = append(valueValidators, v)
+	}
+
+	if isValueNot(t.Not) {
+		valueValidators = append(valueValidators, g.valueNotValidator(declName, varNamePlainValue, t.Not))
+	}
+
+	if len(valueValidators) == 0 {
+		return validators
+	}
+
+	g.output.file.Package.AddImport("encoding/json", "")
+
+	validators = append(validators, &plainValueValidator{})
+
+	return append(validators, valueValidators...)
+}
+
+// declaredConstValidators returns the validator of the const value of a
+// declared type, which compares the given value as JSON when the const has no
+// literal of the Go type.
+func (g *schemaGenerator) declaredConstValidators(
+	declName string,
+	t *schemas.Type,
+	tt codegen.Type,
+	value string,
+) []validator {
+	if t.Const == nil {
+		return nil
+	}
+
+	v := newConstValidator(codegen.StructField{JSONName: declName, Type: tt, SchemaType: t})
+	if v.literal == "" {
+		v.value = value
+		g.output.file.Package.AddImport("encoding/json", "")
+	}
+
+	return []validator{v}
+}
+
+func (g *schemaGenerator) containsValidator(jsonName string, t *schemas.Type) *containsValidator {
+	v := newContainsValidator(jsonName, t)
+	for _, check := range v.checks {
+		for _, pkg := range check.imports {
+			g.output.file.Package.AddImport(pkg, "")
+		}
+	}
+
+	return v
+}
+
+// objectValidators returns the validators of the constraints on the
+// properties of an object, whether it is declared as a struct or a map.
+func (g *schemaGenerator) objectValidators(declName string, t *schemas.Type) []validator {
+	v := newObjectValidator(declName, t)
+	if v.isEmpty() {
+		return nil
+	}
+
+	if v.namePattern != "" {
+		g.output.file.Package.AddImport("regexp", "")
+	}
+
+	if v.nameValues != nil {
+		g.output.file.Package.AddImport("slices", "")
+	}
+
+	return []validator{v}
+}
+
 func (g *schemaGenerator) generateUnmarshaler(decl codegen.TypeDecl, validators []validator) {
 	if g.config.OnlyModels {
 		return
@@ -484,7 +777,25 @@ func (g *schemaGenerator) generateUnmarshaler(decl codegen.TypeDecl, validators
 	}
 }
 
-func (g *schemaGenerator) generateType(t *schemas.Type, scope nameScope) (codegen.Type, error) {
+// generateMarshaler generates marshalers that fill in the const fields, so
+// that a zero value still marshals to a valid document, and that add the
+// properties held by the pattern fields.
+func (g *schemaGenerator) generateMarshaler(decl codegen.TypeDecl, constFields []constField, patternFields []patternField) {
+	for _, formatter := range g.formatters {
+		formatter.addImport(g.output.file)
+
+		g.output.file.Package.AddDecl(&codegen.Method{
+			Impl: formatter.marshal(g.output, decl, constFields, patternFields),
+			Name: decl.GetName() + "_marshal",
+		})
+	}
+}
+
+func (g *schemaGenerator) generateType(t *schemas.Type, scope nameScope) (_ codegen.Type, err error) {
+	defer func() {
+		err = g.located(t, err)
+	}()
+
 	if ext := t.GoJSONSchemaExtension; ext != nil {
 		for _, pkg := range ext.Imports {
 			g.output.file.Package.AddImport(pkg, "")
@@ -507,11 +818,16 @@ func (g *schemaGenerator) generateType(t *schemas.Type, scope nameScope) (codege
 
 	switch typeName {
 	case schemas.TypeNameArray:
-		if t.Items == nil {
+		items := arrayItemsType(t)
+		if items == nil {
+			if len(t.PrefixItems) > 0 {
+				return codegen.ArrayType{Type: codegen.EmptyInterfaceType{}}, nil
+			}
+
 			return nil, errArrayPropertyItems
 		}
 
-		elemType, err := g.generateType(t.Items, scope.add("Elem"))
+		elemType, err := g.generateType(items, scope.add("Elem"))
 		if err != nil {
 			return nil, err
 		}
@@ -613,15 +929,17 @@ func (g *schemaGenerator) determineTypeName(t *schemas.Type) (string, bool) {
 		return t.Type[tidx], isPtr
 	}
 
-	g.warner("Property has multiple types; will be represented as interface{} with no validation")
+	g.warn(t, "Property has multiple types; will be represented as interface{} with no validation")
 
 	return schemas.TypeNameNull, false
 }
 
 func (g *schemaGenerator) generateStructType(t *schemas.Type, scope nameScope) (codegen.Type, error) {
-	if len(t.Properties) == 0 && len(t.AllOf) == 0 && len(t.AnyOf) == 0 && len(t.OneOf) == 0 {
+	mapValue, isMap := patternMapValueType(t)
+
+	if len(t.Properties) == 0 && len(t.AllOf) == 0 && len(t.AnyOf) == 0 && len(t.OneOf) == 0 && isMap {
 		if len(t.Required) > 0 {
-			g.warner("Object type with no properties has required fields; " +
+			g.warn(t, "Object type with no properties has required fields; "+
 				"skipping validation code for them since we don't know their types")
 		}
 
@@ -629,8 +947,8 @@ func (g *schemaGenerator) generateStructType(t *schemas.Type, scope nameScope) (
 
 		var err error
 
-		if t.AdditionalProperties != nil {
-			if valueType, err = g.generateType(t.AdditionalProperties, scope.add("Value")); err != nil {
+		if mapValue != nil {
+			if valueType, err = g.generateType(mapValue, scope.add("Value")); err != nil {
 				return nil, err
 			}
 		}
@@ -669,6 +987,12 @@ func (g *schemaGenerator) generateStructType(t *schemas.Type, scope nameScope) (
 		return g.generateOneOfType(t, scope)
 	}
 
+	if len(t.PatternProperties) > 0 {
+		if err := g.addPatternFields(&structType, t, scope, uniqueNames); err != nil {
+			return nil, err
+		}
+	}
+
 	// Checking .Not here because `false` is unmarshalled to .Not = Type{}.
 	if t.AdditionalProperties != nil && t.AdditionalProperties.Not == nil {
 		if len(t.AdditionalProperties.Type) > 1 {
@@ -781,8 +1105,8 @@ func (g *schemaGenerator) addStructField(
 	if count, ok := uniqueNames[fieldName]; ok {
 		uniqueNames[fieldName] = count + 1
 		fieldName = fmt.Sprintf("%s_%d", fieldName, count+1)
-		g.warner(fmt.Sprintf("Field %q maps to a field by the same name declared "+
-			"in the same struct; it will be declared as %s", name, fieldName))
+		g.warn(prop, "Field %q maps to a field by the same name declared "+
+			"in the same struct; it will be declared as %s", name, fieldName)
 	} else {
 		uniqueNames[fieldName] = 1
 	}
@@ -817,6 +1141,10 @@ func (g *schemaGenerator) addStructField(
 
 	structField.Type, err = g.generateTypeInline(prop, scope.add(structField.Name))
 	if err != nil {
+		if isLocated(err) {
+			return err
+		}
+
 		return fmt.Errorf("could not generate type for field %q: %w", name, err)
 	}
 
@@ -906,6 +1234,10 @@ func (g *schemaGenerator) generateOneOfType(oneOf *schemas.Type, scope nameScope
 
 		paramType, err := g.generateTypeInline(&attr.Type, scope.add(upperCaseName))
 		if err != nil {
+			if isLocated(err) {
+				return nil, err
+			}
+
 			return nil, fmt.Errorf("could not generate type for field %q: %w", attr.Name, err)
 		}
 
@@ -929,16 +1261,26 @@ func (g *schemaGenerator) generateOneOfType(oneOf *schemas.Type, scope nameScope
 				Nillable: paramType.IsNillable(),
 			}
 
-			for key, value := range attr.ConstantValuesMap {
-				constantName := scope.add(upperCaseName).add(g.caser.Identifierize(value)).string()
+			constantNames := map[string]string{}
 
-				constant := codegen.Constant{
-					Name:  constantName,
-					Value: value,
-					Type:  tdecl,
-				}
+			for _, key := range slices.Sorted(maps.Keys(attr.ConstantValuesMap)) {
+				value := attr.ConstantValuesMap[key]
+
+				// Const values are decoded from JSON, so they always marshal.
+				canonical, _ := json.Marshal(value) //nolint:errchkjson // see above
 
-				g.output.file.Package.AddDecl(&constant)
+				constantName, ok := constantNames[string(canonical)]
+				if !ok {
+					constantName = g.output.uniqueValueName(
+						scope.add(upperCaseName).add(g.constValueName(value)).string(), g.origin(&attr.Type))
+					constantNames[string(canonical)] = constantName
+
+					g.output.file.Package.AddDecl(&codegen.Constant{
+						Name:  constantName,
+						Value: value,
+						Type:  tdecl,
+					})
+				}
 
 				g.output.file.Package.AddDecl(&codegen.Method{
 					Impl: func(out *codegen.Emitter) {
@@ -1019,7 +1361,8 @@ func (g *schemaGenerator) defaultPropertyValue(prop *schemas.Type) any {
 		}
 
 		if len(prop.AdditionalProperties.Type) != 1 {
-			g.warner("Additional property has multiple types; will be represented as an empty interface with no validation")
+			g.warn(prop.AdditionalProperties,
+				"Additional property has multiple types; will be represented as an empty interface with no validation")
 
 			return prop.Default
 		}
@@ -1048,7 +1391,11 @@ func (g *schemaGenerator) defaultPropertyValue(prop *schemas.Type) any {
 	return prop.Default
 }
 
-func (g *schemaGenerator) generateTypeInline(t *schemas.Type, scope nameScope) (codegen.Type, error) {
+func (g *schemaGenerator) generateTypeInline(t *schemas.Type, scope nameScope) (_ codegen.Type, err error) {
+	defer func() {
+		err = g.located(t, err)
+	}()
+
 	if t.Enum == nil && t.Ref == "" {
 		if ext := t.GoJSONSchemaExtension; ext != nil {
 			for _, pkg := range ext.Imports {
@@ -1089,7 +1436,7 @@ func (g *schemaGenerator) generateTypeInline(t *schemas.Type, scope nameScope) (
 		}
 
 		if len(t.Type) > 1 && !typeShouldBePointer {
-			g.warner(fmt.Sprintf("Property %v has multiple types; will be represented as interface{} with no validation", scope))
+			g.warn(t, "Property %v has multiple types; will be represented as interface{} with no validation", scope)
 
 			return codegen.EmptyInterfaceType{}, nil
 		}
@@ -1131,12 +1478,12 @@ func (g *schemaGenerator) generateTypeInline(t *schemas.Type, scope nameScope) (
 		if t.Type[typeIndex] == schemas.TypeNameArray {
 			var theType codegen.Type
 
-			if t.Items == nil {
+			if items := arrayItemsType(t); items == nil {
 				theType = codegen.EmptyInterfaceType{}
 			} else {
 				var err error
 
-				theType, err = g.generateTypeInline(t.Items, scope.add("Elem"))
+				theType, err = g.generateTypeInline(items, scope.add("Elem"))
 				if err != nil {
 					return nil, err
 				}
@@ -1190,7 +1537,7 @@ func (g *schemaGenerator) generateEnumType(t *schemas.Type, scope nameScope) (co
 	} else {
 		if len(t.Type) > 1 {
 			// TODO: Support multiple types.
-			g.warner("Enum defined with multiple types; ignoring it and using enum values instead")
+			g.warn(t, "Enum defined with multiple types; ignoring it and using enum values instead")
 		}
 
 		var primitiveType string
@@ -1233,7 +1580,7 @@ func (g *schemaGenerator) generateEnumType(t *schemas.Type, scope nameScope) (co
 	}
 
 	if wrapInStruct {
-		g.warner("Enum field wrapped in struct in order to store values of multiple types")
+		g.warn(t, "Enum field wrapped in struct in order to store values of multiple types")
 
 		enumType = &codegen.StructType{
 			Fields: []codegen.StructField{
@@ -1246,7 +1593,7 @@ func (g *schemaGenerator) generateEnumType(t *schemas.Type, scope nameScope) (co
 	}
 
 	enumDecl := codegen.TypeDecl{
-		Name:       g.output.uniqueTypeName(scope.string()),
+		Name:       g.output.uniqueTypeName(scope.string(), g.origin(t)),
 		Type:       enumType,
 		SchemaType: t,
 	}
@@ -1305,7 +1652,7 @@ func (g *schemaGenerator) resolveRefs(types []*schemas.Type) []*schemas.Type {
 	for _, typ := range types {
 		resolvedType, err := g.resolveRef(typ)
 		if err != nil {
-			g.warner(fmt.Sprintf("Could not resolve ref %q: %v", typ.Ref, err))
+			g.warn(typ, "Could not resolve ref %q: %v", typ.Ref, err)
 
 			continue
 		}
@@ -1359,12 +1706,16 @@ func (g *schemaGenerator) extractPointedType(typ codegen.Type) (*codegen.NamedTy
 }
 
 func (g *schemaGenerator) detectCycle(t *schemas.Type) (bool, func(), error) {
-	defName, filename, err := g.extractRefNames(t)
+	pointer, filename, anchor, err := g.extractRefNames(t)
 	if err != nil {
 		return false, func() {}, err
 	}
 
-	if defName == "" && filename == "" && !t.Dereferenced {
+	if anchor != "" {
+		pointer = "#" + anchor
+	}
+
+	if pointer == "" && filename == "" && !t.Dereferenced {
 		return false, func() {}, nil
 	}
 
@@ -1372,7 +1723,7 @@ func (g *schemaGenerator) detectCycle(t *schemas.Type) (bool, func(), error) {
 		schema:     g.schema,
 		schemaType: t,
 		filename:   filename,
-		name:       defName,
+		name:       pointer,
 	}
 
 	_, isCycle := g.inScope[qual]
@@ -3,6 +3,7 @@ package generator
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/walteh/schema2go/pkg/codegen"
 	"github.com/walteh/schema2go/pkg/mathutils"
+	"github.com/walteh/schema2go/pkg/schemas"
 )
 
 type validator interface {
@@ -31,6 +33,15 @@ var (
 	_ validator = new(stringValidator)
 	_ validator = new(numericValidator)
 	_ validator = new(anyOfValidator)
+	_ validator = new(constValidator)
+	_ validator = new(conditionalValidator)
+	_ validator = new(dependentValidator)
+	_ validator = new(objectValidator)
+	_ validator = new(containsValidator)
+	_ validator = new(notValidator)
+	_ validator = new(patternPropertiesValidator)
+	_ validator = new(patternFieldsValidator)
+	_ validator = new(plainValueValidator)
 )
 
 type requiredValidator struct {
@@ -184,9 +195,15 @@ type arrayValidator struct {
 	arrayDepth int
 	minItems   int
 	maxItems   int
+	// uniqueItems only applies to the outermost array.
+	uniqueItems bool
 }
 
 func (v *arrayValidator) generate(out *codegen.Emitter, format string) {
+	if v.uniqueItems {
+		v.generateUnique(out)
+	}
+
 	if v.minItems == 0 && v.maxItems == 0 {
 		return
 	}
@@ -233,6 +250,26 @@ func (v *arrayValidator) generate(out *codegen.Emitter, format string) {
 	}
 }
 
+// generateUnique compares items by their JSON encoding, so that equal values
+// behind different pointers, or maps in a different order, are duplicates.
+func (v *arrayValidator) generateUnique(out *codegen.Emitter) {
+	seen := "seen" + v.fieldName
+
+	out.Printlnf("%s := map[string]int{}", seen)
+	out.Printlnf("for i, item := range %s {", getPlainName(v.fieldName))
+	out.Indent(1)
+	out.Printlnf("b, err := json.Marshal(item)")
+	out.Printlnf("if err != nil { return err }")
+	out.Printlnf("if first, ok := %s[string(b)]; ok {", seen)
+	out.Indent(1)
+	out.Printlnf(`return fmt.Errorf("field %%s: items %%d and %%d must be unique", "%s", first, i)`, v.jsonName)
+	out.Indent(-1)
+	out.Printlnf("}")
+	out.Printlnf("%s[string(b)] = i", seen)
+	out.Indent(-1)
+	out.Printlnf("}")
+}
+
 func (v *arrayValidator) desc() *validatorDesc {
 	return &validatorDesc{
 		hasError:            true,
@@ -240,6 +277,212 @@ func (v *arrayValidator) desc() *validatorDesc {
 	}
 }
 
+type objectValidator struct {
+	declName      string
+	minProperties int
+	maxProperties int
+	// Constraints of propertyNames.
+	namePattern   string
+	nameMinLength int
+	nameMaxLength int
+	nameValues    []string
+}
+
+func newObjectValidator(declName string, t *schemas.Type) *objectValidator {
+	v := &objectValidator{
+		declName:      declName,
+		minProperties: t.MinProperties,
+		maxProperties: t.MaxProperties,
+	}
+
+	if names := t.PropertyNames; names != nil {
+		v.namePattern = names.Pattern
+		v.nameMinLength = names.MinLength
+		v.nameMaxLength = names.MaxLength
+
+		values := names.Enum
+		if names.Const != nil {
+			values = append(values[:len(values):len(values)], *names.Const)
+		}
+
+		// Other values cannot match a property name, so they are left out;
+		// the empty list that remains allows no names at all.
+		if values != nil {
+			v.nameValues = []string{}
+
+			for _, value := range values {
+				if s, ok := value.(string); ok {
+					v.nameValues = append(v.nameValues, s)
+				}
+			}
+		}
+	}
+
+	return v
+}
+
+func (v *objectValidator) isEmpty() bool {
+	return v.minProperties == 0 && v.maxProperties == 0 && v.namePattern == "" &&
+		v.nameMinLength == 0 && v.nameMaxLength == 0 && v.nameValues == nil
+}
+
+func (v *objectValidator) generate(out *codegen.Emitter, format string) {
+	if v.minProperties != 0 {
+		out.Printlnf(`if %s != nil && len(%s) < %d {`, varNameRawMap, varNameRawMap, v.minProperties)
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("%s: number of properties must be >= %%d", %d)`, v.declName, v.minProperties)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	if v.maxProperties != 0 {
+		out.Printlnf(`if len(%s) > %d {`, varNameRawMap, v.maxProperties)
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("%s: number of properties must be <= %%d", %d)`, v.declName, v.maxProperties)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	if v.namePattern == "" && v.nameMinLength == 0 && v.nameMaxLength == 0 && v.nameValues == nil {
+		return
+	}
+
+	out.Printlnf(`for key := range %s {`, varNameRawMap)
+	out.Indent(1)
+
+	if v.namePattern != "" {
+		out.Printlnf(`if matched, _ := regexp.MatchString(`+"`%s`"+`, key); !matched {`, v.namePattern)
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("property name %%q in %s: must match %%s", key, `+"`%s`"+`)`,
+			v.declName, v.namePattern)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	if v.nameMinLength != 0 {
+		out.Printlnf(`if len(key) < %d {`, v.nameMinLength)
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("property name %%q in %s: length must be >= %%d", key, %d)`,
+			v.declName, v.nameMinLength)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	if v.nameMaxLength != 0 {
+		out.Printlnf(`if len(key) > %d {`, v.nameMaxLength)
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("property name %%q in %s: length must be <= %%d", key, %d)`,
+			v.declName, v.nameMaxLength)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	if v.nameValues != nil {
+		out.Printlnf(`if !slices.Contains(%#v, key) {`, v.nameValues)
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("property name %%q in %s: must be one of %%q", key, %#v)`,
+			v.declName, v.nameValues)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	out.Indent(-1)
+	out.Printlnf("}")
+}
+
+func (v *objectValidator) desc() *validatorDesc {
+	return &validatorDesc{
+		hasError:            true,
+		beforeJSONUnmarshal: true,
+	}
+}
+
+// containsValidator counts the items of an array that match the contains
+// schema, on the raw value, so that the schema applies to any item type.
+type containsValidator struct {
+	jsonName    string
+	checks      []schemaCheck
+	minContains int
+	maxContains *int
+	// value is the generic value of a declared array type; without it, the
+	// items are those of the field in the raw map of an object.
+	value string
+}
+
+func newContainsValidator(jsonName string, t *schemas.Type) *containsValidator {
+	v := &containsValidator{
+		jsonName:    jsonName,
+		checks:      valueChecks("item", t.Contains),
+		minContains: 1,
+		maxContains: t.MaxContains,
+	}
+
+	if t.MinContains != nil {
+		v.minContains = *t.MinContains
+	}
+
+	return v
+}
+
+func (v *containsValidator) generate(out *codegen.Emitter, format string) {
+	condition := describeChecks(v.checks)
+	if len(v.checks) == 0 {
+		condition = "item is anything"
+	}
+
+	if v.value != "" {
+		out.Printlnf(`if items, ok := %s.([]interface{}); ok {`, v.value)
+	} else {
+		out.Printlnf(`if items, ok := %s["%s"].([]interface{}); ok {`, varNameRawMap, v.jsonName)
+	}
+
+	out.Indent(1)
+	out.Printlnf("var matches []int")
+	out.Printlnf("for i, item := range items {")
+	out.Indent(1)
+	out.Printlnf("matched := true")
+
+	for _, check := range v.checks {
+		check.emit(out, "item", func() {
+			out.Printlnf("matched = false")
+		})
+	}
+
+	out.Printlnf("if matched { matches = append(matches, i) }")
+	out.Indent(-1)
+	out.Printlnf("}")
+
+	if v.minContains > 0 {
+		out.Printlnf("if len(matches) < %d {", v.minContains)
+		out.Indent(1)
+		out.Printlnf(
+			`return fmt.Errorf("field %%s: must contain >= %%d items where %%s, found %%d at indices %%v", "%s", %d, %s, len(matches), matches)`,
+			v.jsonName, v.minContains, strconv.Quote(condition))
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	if v.maxContains != nil {
+		out.Printlnf("if len(matches) > %d {", *v.maxContains)
+		out.Indent(1)
+		out.Printlnf(
+			`return fmt.Errorf("field %%s: must contain <= %%d items where %%s, found %%d at indices %%v", "%s", %d, %s, len(matches), matches)`,
+			v.jsonName, *v.maxContains, strconv.Quote(condition))
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	out.Indent(-1)
+	out.Printlnf("}")
+}
+
+func (v *containsValidator) desc() *validatorDesc {
+	return &validatorDesc{
+		hasError:            true,
+		beforeJSONUnmarshal: v.value == "",
+	}
+}
+
 type stringValidator struct {
 	jsonName   string
 	fieldName  string
@@ -318,9 +561,9 @@ type numericValidator struct {
 	isNillable       bool
 	multipleOf       *float64
 	maximum          *float64
-	exclusiveMaximum *any
+	exclusiveMaximum *float64
 	minimum          *float64
-	exclusiveMinimum *any
+	exclusiveMinimum *float64
 	roundToInt       bool
 }
 
@@ -369,7 +612,6 @@ func (v *numericValidator) genBoundary(
 		return
 	}
 
-	// Technically, this should be based on schema version, but that information is lost.
 	comp := sign
 	if exclusive {
 		// We're putting the other number first, so we need the = if it's exclusive.
@@ -400,6 +642,31 @@ func (v *numericValidator) valueOf(val float64) any {
 	return val
 }
 
+// plainValueValidator decodes the unmarshaled value of a declared type that is
+// not a struct into a generic value, through its JSON encoding, for the
+// validators that check the value as a whole.
+type plainValueValidator struct{}
+
+func (v *plainValueValidator) generate(out *codegen.Emitter, format string) {
+	out.Printlnf("var %s interface{}", varNamePlainValue)
+	out.Printlnf("if b, err := json.Marshal(%s); err != nil {", varNamePlainStruct)
+	out.Indent(1)
+	out.Printlnf("return err")
+	out.Indent(-1)
+	out.Printlnf("} else if err := json.Unmarshal(b, &%s); err != nil {", varNamePlainValue)
+	out.Indent(1)
+	out.Printlnf("return err")
+	out.Indent(-1)
+	out.Printlnf("}")
+}
+
+func (v *plainValueValidator) desc() *validatorDesc {
+	return &validatorDesc{
+		hasError:            false,
+		beforeJSONUnmarshal: false,
+	}
+}
+
 func getPlainName(fieldName string) string {
 	if fieldName == "" {
 		return varNamePlainStruct
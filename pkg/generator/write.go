@@ -0,0 +1,198 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/walteh/schema2go/pkg/codegen"
+)
+
+const generatedHeader = "// " + codegen.GeneratedComment
+
+// WriteSources writes every generated source to disk; see WriteSources.
+func (g *Generator) WriteSources(dir string) ([]string, error) {
+	return WriteSources(dir, g.Sources())
+}
+
+// RemoveStaleSources deletes generated files that the generator no longer
+// produces; see RemoveStaleSources.
+func (g *Generator) RemoveStaleSources(dir string) ([]string, error) {
+	return RemoveStaleSources(dir, g.Sources())
+}
+
+// WriteSources writes generated sources, keyed by file name, and returns the
+// names of the files it wrote. Relative file names are resolved against dir,
+// and missing directories are created. Sources meant for standard output ("-")
+// are skipped, as are files whose contents on disk are already up to date.
+//
+// Every file is written to a temporary file next to it and then renamed into
+// place, so that readers never observe a partially written file.
+func WriteSources(dir string, sources map[string][]byte) ([]string, error) {
+	fileNames := make([]string, 0, len(sources))
+
+	for fileName := range sources {
+		if fileName != "-" {
+			fileNames = append(fileNames, fileName)
+		}
+	}
+
+	sort.Strings(fileNames)
+
+	var written []string
+
+	for _, fileName := range fileNames {
+		path := outputPath(dir, fileName)
+		source := withGeneratedHeader(sources[fileName])
+
+		if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, source) {
+			continue
+		}
+
+		if err := writeFileAtomic(path, source); err != nil {
+			return written, err
+		}
+
+		written = append(written, path)
+	}
+
+	return written, nil
+}
+
+// RemoveStaleSources deletes files left over from a previous run: Go files
+// that carry the schema2go header but are not among the given sources. Only
+// the directories of the given sources are searched, not their
+// subdirectories, and relative file names are resolved against dir, as in
+// WriteSources. It returns the names of the files it removed.
+//
+// Any generated file in those directories counts as stale, including one
+// written by a separate schema2go invocation, so this is only safe when a
+// single run owns all of them.
+func RemoveStaleSources(dir string, sources map[string][]byte) ([]string, error) {
+	current := map[string]bool{}
+	dirs := map[string]bool{}
+
+	for fileName := range sources {
+		if fileName == "-" {
+			continue
+		}
+
+		path := filepath.Clean(outputPath(dir, fileName))
+		current[path] = true
+		dirs[filepath.Dir(path)] = true
+	}
+
+	var removed []string
+
+	for _, d := range sortedKeys(dirs) {
+		entries, err := os.ReadDir(d)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return removed, fmt.Errorf("failed to read directory %s: %w", d, err)
+		}
+
+		for _, entry := range entries {
+			path := filepath.Join(d, entry.Name())
+
+			if entry.IsDir() || filepath.Ext(path) != ".go" || current[path] {
+				continue
+			}
+
+			generated, err := isGeneratedFile(path)
+			if err != nil {
+				return removed, err
+			}
+
+			if !generated {
+				continue
+			}
+
+			if err := os.Remove(path); err != nil {
+				return removed, fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+
+			removed = append(removed, path)
+		}
+	}
+
+	return removed, nil
+}
+
+func outputPath(dir, fileName string) string {
+	if dir == "" || filepath.IsAbs(fileName) {
+		return fileName
+	}
+
+	return filepath.Join(dir, fileName)
+}
+
+func withGeneratedHeader(source []byte) []byte {
+	if bytes.HasPrefix(source, []byte(generatedHeader+"\n")) {
+		return source
+	}
+
+	return append([]byte(generatedHeader+"\n\n"), source...)
+}
+
+func isGeneratedFile(fileName string) (bool, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s: %w", fileName, err)
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && line == "" {
+		return false, nil //nolint:nilerr // an empty or unreadable first line is simply not our header
+	}
+
+	return strings.TrimRight(line, "\r\n") == generatedHeader, nil
+}
+
+func writeFileAtomic(fileName string, data []byte) error {
+	dir := filepath.Dir(fileName)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", fileName, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(fileName)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %s: %w", fileName, err)
+	}
+
+	tmpName := tmp.Name()
+
+	// Remove the temporary file on any failure; after a successful rename this
+	// is a no-op.
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("failed to write %s: %w", fileName, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", fileName, err)
+	}
+
+	if err := os.Chmod(tmpName, 0o644); err != nil { //nolint:gosec // generated code is not secret
+		return fmt.Errorf("failed to set permissions on %s: %w", fileName, err)
+	}
+
+	if err := os.Rename(tmpName, fileName); err != nil {
+		return fmt.Errorf("failed to write %s: %w", fileName, err)
+	}
+
+	return nil
+}
@@ -106,6 +106,33 @@ func (yf *yamlFormatter) enumMarshal(declType codegen.TypeDecl) func(*codegen.Em
 	}
 }
 
+func (yf *yamlFormatter) marshal(
+	output *output,
+	declType codegen.TypeDecl,
+	constFields []constField,
+	patternFields []patternField,
+) func(*codegen.Emitter) {
+	return func(out *codegen.Emitter) {
+		out.Commentf("Marshal%s implements %s.Marshaler; %s.",
+			strings.ToUpper(formatYAML), formatYAML, marshalerPurpose(constFields, patternFields))
+		out.Printlnf("func (j %s) Marshal%s() (interface{}, error) {", declType.Name, strings.ToUpper(formatYAML))
+		out.Indent(1)
+		emitMarshalPlain(out, output, declType, constFields)
+
+		if len(patternFields) == 0 {
+			out.Printlnf("return %s, nil", varNamePlainStruct)
+		} else {
+			out.Printlnf("b, err := %s.Marshal(%s)", formatYAML, varNamePlainStruct)
+			out.Printlnf("if err != nil { return nil, err }")
+			emitAddPatternProperties(out, formatYAML, patternFields)
+			out.Printlnf("return properties, nil")
+		}
+
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+}
+
 func (yf *yamlFormatter) enumUnmarshal(
 	declType codegen.TypeDecl,
 	enumType codegen.Type,
@@ -14,17 +14,10 @@ func TestNormalizeBounds(t *testing.T) {
 	anyMin := 100.0
 	anyMax := 200.0
 
-	var anySmallerMin any = 90.0
-
-	var anyLargerMax any = 210.0
-
-	var anyLargerMin any = 110.0
-
-	var anySmallerMax any = 190.0
-
-	var tr any = true
-
-	var fa any = false
+	anySmallerMin := 90.0
+	anyLargerMax := 210.0
+	anyLargerMin := 110.0
+	anySmallerMax := 190.0
 
 	t.Run("No exclusive bounds", func(t *testing.T) {
 		t.Parallel()
@@ -74,10 +67,10 @@ func TestNormalizeBounds(t *testing.T) {
 		assert.True(t, nMaxExclusive)
 	})
 
-	t.Run("Exclusive bounds as bools", func(t *testing.T) {
+	t.Run("Exclusive bounds equal to the inclusive ones", func(t *testing.T) {
 		t.Parallel()
 
-		nMin, nMax, nMinExclusive, nMaxExclusive := mathutils.NormalizeBounds(&anyMin, &anyMax, &tr, &tr)
+		nMin, nMax, nMinExclusive, nMaxExclusive := mathutils.NormalizeBounds(&anyMin, &anyMax, &anyMin, &anyMax)
 		assert.NotNil(t, nMin)
 		assert.InEpsilon(t, anyMin, *nMin, 0.000001)
 		assert.NotNil(t, nMax)
@@ -86,18 +79,6 @@ func TestNormalizeBounds(t *testing.T) {
 		assert.True(t, nMaxExclusive)
 	})
 
-	t.Run("Exclusive bounds as false bools", func(t *testing.T) {
-		t.Parallel()
-
-		nMin, nMax, nMinExclusive, nMaxExclusive := mathutils.NormalizeBounds(&anyMin, &anyMax, &fa, &fa)
-		assert.NotNil(t, nMin)
-		assert.InEpsilon(t, anyMin, *nMin, 0.000001)
-		assert.NotNil(t, nMax)
-		assert.InEpsilon(t, anyMax, *nMax, 0.000001)
-		assert.False(t, nMinExclusive)
-		assert.False(t, nMaxExclusive)
-	})
-
 	t.Run("No bounds", func(t *testing.T) {
 		t.Parallel()
 
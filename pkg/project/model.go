@@ -0,0 +1,213 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package project
+
+import "encoding/json"
+import "fmt"
+
+// Describes a set of schema2go generation jobs that run together.
+type Config struct {
+	// Options applied to every job unless the job overrides them.
+	Defaults *Options `json:"defaults,omitempty"`
+
+	// Generation jobs, run in order.
+	Jobs []Job `json:"jobs"`
+
+	// URL prefixes mapped to the directories, relative to the project file, that the
+	// schemas under them are read from instead of being fetched. Shared by all jobs.
+	Mirrors ConfigMirrors `json:"mirrors,omitempty"`
+
+	// Restricts the schemas that may be loaded, such as when generating from schemas
+	// submitted by others in CI. Shared by all jobs.
+	Policy *Policy `json:"policy,omitempty"`
+
+	// File extensions to try when resolving $ref paths. Shared by all jobs.
+	ResolveExtensions []string `json:"resolveExtensions,omitempty"`
+
+	// File extensions that are parsed as YAML. Shared by all jobs.
+	YamlExtensions []string `json:"yamlExtensions,omitempty"`
+}
+
+// URL prefixes mapped to the directories, relative to the project file, that the
+// schemas under them are read from instead of being fetched. Shared by all jobs.
+type ConfigMirrors map[string]string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Config) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["jobs"]; raw != nil && !ok {
+		return fmt.Errorf("field jobs in Config: required")
+	}
+	type Plain Config
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if plain.Jobs != nil && len(plain.Jobs) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "jobs", 1)
+	}
+	if v, ok := raw["resolveExtensions"]; !ok || v == nil {
+		plain.ResolveExtensions = []string{
+			".json",
+			".yaml",
+			".yml",
+		}
+	}
+	if v, ok := raw["yamlExtensions"]; !ok || v == nil {
+		plain.YamlExtensions = []string{
+			".yaml",
+			".yml",
+		}
+	}
+	*j = Config(plain)
+	return nil
+}
+
+type Job struct {
+	// Glob patterns matching schema files, relative to the project file.
+	Globs []string `json:"globs,omitempty"`
+
+	// Schema files, relative to the project file.
+	Inputs []string `json:"inputs,omitempty"`
+
+	// Name of the job, used in messages.
+	Name *string `json:"name,omitempty"`
+
+	// Options for this job; unset values fall back to the project defaults.
+	Options *Options `json:"options,omitempty"`
+
+	// Default output file, relative to the project file.
+	Output string `json:"output"`
+
+	// Default Go package for the generated code.
+	Package string `json:"package"`
+
+	// Per-schema package, output and root type overrides.
+	SchemaMappings []SchemaMapping `json:"schemaMappings,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Job) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["output"]; raw != nil && !ok {
+		return fmt.Errorf("field output in Job: required")
+	}
+	if _, ok := raw["package"]; raw != nil && !ok {
+		return fmt.Errorf("field package in Job: required")
+	}
+	type Plain Job
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if len(plain.Output) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "output", 1)
+	}
+	if len(plain.Package) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "package", 1)
+	}
+	*j = Job(plain)
+	return nil
+}
+
+type Options struct {
+	// Words that should be fully capitalized in identifiers.
+	Capitalizations []string `json:"capitalizations,omitempty"`
+
+	// Also generate YAML unmarshalers.
+	ExtraImports *bool `json:"extraImports,omitempty"`
+
+	// Use the smallest int type that fits the schema bounds.
+	MinSizedInts *bool `json:"minSizedInts,omitempty"`
+
+	// Generate types only, without unmarshal and validation methods.
+	OnlyModels *bool `json:"onlyModels,omitempty"`
+
+	// Name root types after the schema title.
+	StructNameFromTitle *bool `json:"structNameFromTitle,omitempty"`
+
+	// Struct tags to generate.
+	Tags []string `json:"tags,omitempty"`
+
+	// Check the schemas against the meta-schema of their draft before generating;
+	// true unless set to false.
+	ValidateSchemas *bool `json:"validateSchemas,omitempty"`
+}
+
+type Policy struct {
+	// URL hosts allowed for remote schemas, with their port if any; none allows any
+	// host.
+	Hosts []string `json:"hosts,omitempty"`
+
+	// Maximum size of a schema document in bytes; 0 means no limit.
+	MaxBytes *int `json:"maxBytes,omitempty"`
+
+	// Maximum depth of a $ref from an input schema; 0 means no limit.
+	MaxDepth *int `json:"maxDepth,omitempty"`
+
+	// Directories, relative to the project file, that local schema files must be in;
+	// none allows any directory.
+	Roots []string `json:"roots,omitempty"`
+
+	// URL schemes allowed for remote schemas, such as https; none allows any scheme.
+	Schemes []string `json:"schemes,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Policy) UnmarshalJSON(value []byte) error {
+	type Plain Policy
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if plain.MaxBytes != nil && 0 > *plain.MaxBytes {
+		return fmt.Errorf("field %s: must be >= %v", "maxBytes", 0)
+	}
+	if plain.MaxDepth != nil && 0 > *plain.MaxDepth {
+		return fmt.Errorf("field %s: must be >= %v", "maxDepth", 0)
+	}
+	*j = Policy(plain)
+	return nil
+}
+
+type SchemaMapping struct {
+	// Output file for the schema, relative to the project file; defaults to the job
+	// output.
+	Output *string `json:"output,omitempty"`
+
+	// Go package for the schema; defaults to the job package.
+	Package *string `json:"package,omitempty"`
+
+	// Name of the root type generated for the schema.
+	RootType *string `json:"rootType,omitempty"`
+
+	// The $id of the schema this mapping applies to.
+	SchemaId string `json:"schemaId"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *SchemaMapping) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["schemaId"]; raw != nil && !ok {
+		return fmt.Errorf("field schemaId in SchemaMapping: required")
+	}
+	type Plain SchemaMapping
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if len(plain.SchemaId) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "schemaId", 1)
+	}
+	*j = SchemaMapping(plain)
+	return nil
+}
@@ -0,0 +1,388 @@
+// Package project loads schema2go project files (schema2go.yaml), which
+// describe several generation jobs that run together.
+//
+// The file format is described by schema2go.schema.json; the Go types in
+// model.go are generated from it by schema2go itself.
+package project
+
+//go:generate go run ../../cmd/schema2go -p github.com/walteh/schema2go/pkg/project -o model.go -tags json -schema-root-type https://github.com/walteh/schema2go/schema2go.schema.json=Config schema2go.schema.json
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/walteh/schema2go/pkg/generator"
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+// DefaultFileName is the conventional name of a project file.
+const DefaultFileName = "schema2go.yaml"
+
+const stdoutOutput = "-"
+
+var (
+	ErrInvalidProject  = errors.New("invalid project file")
+	ErrUnknownField    = errors.New("unknown field")
+	ErrNoJobInputs     = errors.New("job has no inputs")
+	ErrDuplicateOutput = errors.New("output is generated by more than one job")
+)
+
+// Project is a parsed project file. Relative paths in it are resolved
+// against Dir.
+type Project struct {
+	Config
+	Dir string
+}
+
+// Load reads and validates a project file, which may be YAML or JSON.
+func Load(fileName string) (*Project, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project file: %w", err)
+	}
+
+	p, err := Parse(data, filepath.Dir(fileName))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	return p, nil
+}
+
+// Parse validates a YAML or JSON project document against the project
+// schema.
+func Parse(data []byte, dir string) (*Project, error) {
+	// JSON is valid YAML, so both formats go through the same conversion.
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProject, err)
+	}
+
+	var raw any
+	if err := json.Unmarshal(jsonData, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProject, err)
+	}
+
+	// The schema forbids additional properties everywhere, which the generated
+	// types don't enforce on their own.
+	if err := checkUnknownFields(raw, reflect.TypeOf(Config{}), ""); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProject, err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(jsonData, &cfg); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProject, err)
+	}
+
+	return &Project{Config: cfg, Dir: dir}, nil
+}
+
+// Generate runs every job in order and returns the generated sources keyed
+// by output path. All jobs share a single schema loader, so a schema that is
+// referenced from several jobs is only parsed once.
+func (p *Project) Generate(warner func(string)) (map[string][]byte, error) {
+	loader := p.NewLoader()
+
+	sources := map[string][]byte{}
+	producedBy := map[string]string{}
+
+	for i := range p.Jobs {
+		name := p.JobName(i)
+
+		jobSources, err := p.GenerateJob(i, loader, warner)
+		if err != nil {
+			return nil, err
+		}
+
+		for fileName, source := range jobSources {
+			if other, ok := producedBy[fileName]; ok {
+				return nil, fmt.Errorf("%w: %s (jobs %s and %s)", ErrDuplicateOutput, fileName, other, name)
+			}
+
+			producedBy[fileName] = name
+			sources[fileName] = source
+		}
+	}
+
+	return sources, nil
+}
+
+// NewLoader returns a caching schema loader configured with the project's
+// extensions, policy and mirrors.
+func (p *Project) NewLoader() *schemas.CachedLoader {
+	loader := schemas.NewDefaultMultiLoader(p.ResolveExtensions, p.YamlExtensions)
+
+	if p.Policy != nil {
+		loader.Restrict(p.policy(), p.ResolveExtensions)
+	}
+
+	if len(p.Mirrors) > 0 {
+		mirrors := make(map[string]string, len(p.Mirrors))
+
+		for prefix, dir := range p.Mirrors {
+			mirrors[prefix] = p.path(dir)
+			// The rest of the URL is appended to the directory as it is.
+			if strings.HasSuffix(dir, "/") {
+				mirrors[prefix] += "/"
+			}
+		}
+
+		loader.Mirror(mirrors, loader[schemas.RefTypeFile])
+	}
+
+	cached := schemas.NewCachedLoader(loader, map[string]*schemas.Schema{})
+	cached.ResolveExtensions = p.ResolveExtensions
+
+	return cached
+}
+
+func (p *Project) policy() schemas.Policy {
+	roots := make([]string, 0, len(p.Policy.Roots))
+	for _, root := range p.Policy.Roots {
+		roots = append(roots, p.path(root))
+	}
+
+	return schemas.Policy{
+		Roots:    roots,
+		Schemes:  p.Policy.Schemes,
+		Hosts:    p.Policy.Hosts,
+		MaxBytes: int64(valueOf(p.Policy.MaxBytes)),
+		MaxDepth: valueOf(p.Policy.MaxDepth),
+	}
+}
+
+// GenerateJob runs a single job using the given loader and returns its
+// sources keyed by output path.
+func (p *Project) GenerateJob(index int, loader schemas.Loader, warner func(string)) (map[string][]byte, error) {
+	job := p.Jobs[index]
+	name := p.JobName(index)
+
+	inputs, err := p.jobInputs(job)
+	if err != nil {
+		return nil, fmt.Errorf("job %s: %w", name, err)
+	}
+
+	g, err := generator.New(p.generatorConfig(job, loader, warner))
+	if err != nil {
+		return nil, fmt.Errorf("job %s: %w", name, err)
+	}
+
+	for _, input := range inputs {
+		if err := g.DoFile(input); err != nil {
+			return nil, fmt.Errorf("job %s: failed to generate from %s: %w", name, input, err)
+		}
+	}
+
+	return g.Sources(), nil
+}
+
+// JobName returns the name of a job, falling back to its position in the
+// project file.
+func (p *Project) JobName(index int) string {
+	if name := p.Jobs[index].Name; name != nil && *name != "" {
+		return *name
+	}
+
+	return fmt.Sprintf("jobs[%d]", index)
+}
+
+func (p *Project) generatorConfig(job Job, loader schemas.Loader, warner func(string)) generator.Config {
+	opts := mergeOptions(p.Defaults, job.Options)
+
+	mappings := make([]generator.SchemaMapping, 0, len(job.SchemaMappings))
+
+	for _, m := range job.SchemaMappings {
+		mapping := generator.SchemaMapping{
+			SchemaID:    m.SchemaId,
+			PackageName: job.Package,
+			OutputName:  p.path(job.Output),
+		}
+
+		if m.Package != nil {
+			mapping.PackageName = *m.Package
+		}
+
+		if m.Output != nil {
+			mapping.OutputName = p.path(*m.Output)
+		}
+
+		if m.RootType != nil {
+			mapping.RootType = *m.RootType
+		}
+
+		mappings = append(mappings, mapping)
+	}
+
+	tags := opts.Tags
+	if tags == nil {
+		tags = []string{"json", "yaml", "mapstructure"}
+	}
+
+	return generator.Config{
+		SchemaMappings:      mappings,
+		ExtraImports:        valueOf(opts.ExtraImports),
+		Capitalizations:     opts.Capitalizations,
+		ResolveExtensions:   p.ResolveExtensions,
+		YAMLExtensions:      p.YamlExtensions,
+		DefaultPackageName:  job.Package,
+		DefaultOutputName:   p.path(job.Output),
+		StructNameFromTitle: valueOf(opts.StructNameFromTitle),
+		Warner:              warner,
+		Tags:                tags,
+		OnlyModels:          valueOf(opts.OnlyModels),
+		MinSizedInts:        valueOf(opts.MinSizedInts),
+		Loader:              loader,
+		ValidateSchemas:     opts.ValidateSchemas == nil || *opts.ValidateSchemas,
+	}
+}
+
+// jobInputs returns the job's explicit inputs followed by its glob matches,
+// without duplicates.
+func (p *Project) jobInputs(job Job) ([]string, error) {
+	var inputs []string
+
+	seen := map[string]bool{}
+
+	add := func(fileName string) {
+		if !seen[fileName] {
+			seen[fileName] = true
+			inputs = append(inputs, fileName)
+		}
+	}
+
+	for _, input := range job.Inputs {
+		add(p.path(input))
+	}
+
+	for _, pattern := range job.Globs {
+		matches, err := filepath.Glob(p.path(pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+
+		sort.Strings(matches)
+
+		for _, m := range matches {
+			add(m)
+		}
+	}
+
+	if len(inputs) == 0 {
+		return nil, ErrNoJobInputs
+	}
+
+	return inputs, nil
+}
+
+func (p *Project) path(fileName string) string {
+	if fileName == stdoutOutput || filepath.IsAbs(fileName) {
+		return fileName
+	}
+
+	return filepath.Join(p.Dir, fileName)
+}
+
+func mergeOptions(defaults, overrides *Options) Options {
+	var result Options
+
+	for _, o := range []*Options{defaults, overrides} {
+		if o == nil {
+			continue
+		}
+
+		if o.Tags != nil {
+			result.Tags = o.Tags
+		}
+
+		if o.Capitalizations != nil {
+			result.Capitalizations = o.Capitalizations
+		}
+
+		if o.ExtraImports != nil {
+			result.ExtraImports = o.ExtraImports
+		}
+
+		if o.MinSizedInts != nil {
+			result.MinSizedInts = o.MinSizedInts
+		}
+
+		if o.OnlyModels != nil {
+			result.OnlyModels = o.OnlyModels
+		}
+
+		if o.StructNameFromTitle != nil {
+			result.StructNameFromTitle = o.StructNameFromTitle
+		}
+
+		if o.ValidateSchemas != nil {
+			result.ValidateSchemas = o.ValidateSchemas
+		}
+	}
+
+	return result
+}
+
+func valueOf[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+
+	return *v
+}
+
+// checkUnknownFields reports object keys that have no matching json tag in
+// the corresponding generated struct.
+func checkUnknownFields(value any, typ reflect.Type, path string) error {
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+		if items, ok := value.([]any); ok && typ.Kind() == reflect.Slice {
+			for i, item := range items {
+				if err := checkUnknownFields(item, typ.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		typ = typ.Elem()
+	}
+
+	obj, ok := value.(map[string]any)
+	if !ok || typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := map[string]reflect.Type{}
+
+	for i := range typ.NumField() {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		fields[name] = typ.Field(i).Type
+	}
+
+	for key, v := range obj {
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+
+		fieldType, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("%w %q", ErrUnknownField, fieldPath)
+		}
+
+		if err := checkUnknownFields(v, fieldType, fieldPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,267 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+func TestParseValidatesAgainstSchema(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		data    string
+		wantErr string
+	}{
+		{
+			desc:    "missing jobs",
+			data:    `defaults: {tags: [json]}`,
+			wantErr: "field jobs in Config: required",
+		},
+		{
+			desc:    "empty jobs",
+			data:    `jobs: []`,
+			wantErr: "field jobs length: must be >= 1",
+		},
+		{
+			desc:    "job without package",
+			data:    "jobs:\n  - output: a.go\n    inputs: [a.json]",
+			wantErr: "field package in Job: required",
+		},
+		{
+			desc:    "mapping without schema id",
+			data:    "jobs:\n  - {package: a, output: a.go, schemaMappings: [{package: b}]}",
+			wantErr: "field schemaId in SchemaMapping: required",
+		},
+		{
+			desc:    "unknown top-level field",
+			data:    "jbos: []",
+			wantErr: `unknown field "jbos"`,
+		},
+		{
+			desc:    "unknown nested field",
+			data:    "jobs:\n  - {package: a, output: a.go, options: {tag: [json]}}",
+			wantErr: `unknown field "jobs[0].options.tag"`,
+		},
+		{
+			desc:    "wrong type",
+			data:    "jobs:\n  - {package: a, output: a.go, options: {onlyModels: yes please}}",
+			wantErr: "cannot unmarshal string",
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := Parse([]byte(tC.data), ".")
+			require.ErrorIs(t, err, ErrInvalidProject)
+			assert.ErrorContains(t, err, tC.wantErr)
+		})
+	}
+}
+
+func TestParseAppliesDefaults(t *testing.T) {
+	p, err := Parse([]byte(`{"jobs": [{"package": "a", "output": "a.go", "inputs": ["a.json"]}]}`), "dir")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{".json", ".yaml", ".yml"}, p.ResolveExtensions)
+	assert.Equal(t, []string{".yaml", ".yml"}, p.YamlExtensions)
+	assert.Equal(t, "dir", p.Dir)
+}
+
+func TestMergeOptions(t *testing.T) {
+	yes, no := true, false
+
+	merged := mergeOptions(
+		&Options{Tags: []string{"json"}, ExtraImports: &yes, OnlyModels: &yes},
+		&Options{Tags: []string{"yaml"}, OnlyModels: &no},
+	)
+
+	assert.Equal(t, []string{"yaml"}, merged.Tags)
+	assert.Equal(t, &yes, merged.ExtraImports)
+	assert.Equal(t, &no, merged.OnlyModels)
+	assert.Nil(t, merged.MinSizedInts)
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, dir, "schemas/shared.json", `{
+		"$id": "https://example.com/shared",
+		"type": "object",
+		"properties": {"id": {"type": "string"}}
+	}`)
+	writeFile(t, dir, "schemas/api/user.json", `{
+		"$id": "https://example.com/user",
+		"type": "object",
+		"properties": {"shared": {"$ref": "../shared.json"}}
+	}`)
+	writeFile(t, dir, "schemas/api/group.json", `{
+		"$id": "https://example.com/group",
+		"type": "object",
+		"properties": {"name": {"type": "string"}}
+	}`)
+	writeFile(t, dir, "schemas/event.json", `{
+		"$id": "https://example.com/event",
+		"type": "object",
+		"properties": {"shared": {"$ref": "shared.json"}}
+	}`)
+	writeFile(t, dir, DefaultFileName, `
+defaults:
+  tags: [json]
+  onlyModels: true
+jobs:
+  - name: api
+    package: github.com/example/api
+    output: api/types.go
+    globs: [schemas/api/*.json]
+    schemaMappings:
+      - schemaId: https://example.com/shared
+        package: github.com/example/shared
+        output: shared/types.go
+  - name: events
+    package: github.com/example/events
+    output: events/types.go
+    inputs: [schemas/event.json]
+    options:
+      tags: [yaml]
+`)
+
+	p, err := Load(filepath.Join(dir, DefaultFileName))
+	require.NoError(t, err)
+
+	sources, err := p.Generate(func(string) {})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "api/types.go"),
+		filepath.Join(dir, "shared/types.go"),
+		filepath.Join(dir, "events/types.go"),
+	}, keys(sources))
+
+	api := string(sources[filepath.Join(dir, "api/types.go")])
+	assert.Contains(t, api, "type Group struct")
+	assert.Contains(t, api, "type User struct")
+	assert.Contains(t, api, "shared.Shared")
+	assert.Contains(t, api, `json:"name,omitempty"`)
+	assert.NotContains(t, api, "UnmarshalJSON", "onlyModels is inherited from the defaults")
+
+	events := string(sources[filepath.Join(dir, "events/types.go")])
+	assert.Contains(t, events, "type Shared struct", "shared.json has no mapping in the events job")
+	assert.Contains(t, events, `yaml:"shared,omitempty"`)
+}
+
+func TestGenerateRejectsDuplicateOutputs(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, dir, "a.json", `{"type": "object", "properties": {"a": {"type": "string"}}}`)
+	writeFile(t, dir, "b.json", `{"type": "object", "properties": {"b": {"type": "string"}}}`)
+
+	p, err := Parse([]byte(`
+jobs:
+  - {name: a, package: example, output: types.go, inputs: [a.json]}
+  - {name: b, package: example, output: types.go, inputs: [b.json]}
+`), dir)
+	require.NoError(t, err)
+
+	_, err = p.Generate(func(string) {})
+	require.ErrorIs(t, err, ErrDuplicateOutput)
+	assert.ErrorContains(t, err, "jobs a and b")
+}
+
+func TestGenerateRequiresInputs(t *testing.T) {
+	p, err := Parse([]byte(`{"jobs": [{"name": "empty", "package": "a", "output": "a.go", "globs": ["*.nope"]}]}`),
+		t.TempDir())
+	require.NoError(t, err)
+
+	_, err = p.Generate(func(string) {})
+	require.ErrorIs(t, err, ErrNoJobInputs)
+	assert.ErrorContains(t, err, "job empty")
+}
+
+func TestGenerateValidatesSchemas(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, dir, "a.json", `{"type": "object", "properties": {"a": {"type": "strin"}}}`)
+
+	p, err := Parse([]byte(`{"jobs": [{"package": "a", "output": "a.go", "inputs": ["a.json"]}]}`), dir)
+	require.NoError(t, err)
+
+	_, err = p.Generate(func(string) {})
+	require.ErrorIs(t, err, schemas.ErrInvalidSchema)
+	assert.ErrorContains(t, err, `did you mean "string"?`)
+
+	p, err = Parse([]byte(`{
+		"defaults": {"validateSchemas": false},
+		"jobs": [{"package": "a", "output": "a.go", "inputs": ["a.json"]}]
+	}`), dir)
+	require.NoError(t, err)
+
+	_, err = p.Generate(func(string) {})
+	require.NotErrorIs(t, err, schemas.ErrInvalidSchema)
+}
+
+func TestGenerateReadsMirrors(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, dir, "schemas/a.json", `{"type": "object", "properties": {"b": {"$ref": "https://schemas.example.com/b.json"}}}`)
+	writeFile(t, dir, "vendor/schemas/b.json", `{"title": "B", "type": "object", "properties": {"c": {"$ref": "c.json"}}}`)
+	writeFile(t, dir, "vendor/schemas/c.json", `{"title": "C", "type": "string"}`)
+
+	// The directory of a prefix that ends with "/" is a directory either way.
+	for _, vendor := range []string{"./vendor/schemas/", "vendor/schemas"} {
+		t.Run(vendor, func(t *testing.T) {
+			p, err := Parse([]byte(`{
+				"mirrors": {"https://schemas.example.com/": "`+vendor+`"},
+				"jobs": [{"package": "a", "output": "a.go", "inputs": ["schemas/a.json"]}]
+			}`), dir)
+			require.NoError(t, err)
+
+			sources, err := p.Generate(func(string) {})
+			require.NoError(t, err)
+			assert.Contains(t, string(sources[filepath.Join(dir, "a.go")]), "type B struct")
+			assert.Contains(t, string(sources[filepath.Join(dir, "a.go")]), "type C string")
+		})
+	}
+}
+
+func TestGenerateAppliesPolicy(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, dir, "schemas/a.json", `{"type": "object", "properties": {"b": {"$ref": "../other/b.json"}}}`)
+	writeFile(t, dir, "other/b.json", `{"type": "string"}`)
+
+	p, err := Parse([]byte(`{
+		"policy": {"roots": ["schemas"]},
+		"jobs": [{"package": "a", "output": "a.go", "inputs": ["schemas/a.json"]}]
+	}`), dir)
+	require.NoError(t, err)
+
+	_, err = p.Generate(func(string) {})
+	require.ErrorIs(t, err, schemas.ErrOutsideRoots)
+
+	p.Policy.Roots = append(p.Policy.Roots, "other")
+
+	_, err = p.Generate(func(string) {})
+	require.NoError(t, err)
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	fileName := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0o755))
+	require.NoError(t, os.WriteFile(fileName, []byte(content), 0o644))
+}
+
+func keys[T any](m map[string]T) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+
+	return result
+}
@@ -0,0 +1,310 @@
+package schemas
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+)
+
+func NewCachedLoader(loader Loader, cache map[string]*Schema) *CachedLoader {
+	return &CachedLoader{
+		loader: loader,
+		cache:  cache,
+		calls:  map[string]*cachedCall{},
+	}
+}
+
+// CachedLoader loads each schema once and returns the same *Schema for every
+// URI that refers to it. It is safe for concurrent use: concurrent loads of
+// the same schema wait for a single call to the underlying loader.
+type CachedLoader struct {
+	// ResolveExtensions are tried when qualifying file names for a loader
+	// that is not a DocumentLoader, so that a file referenced with and
+	// without its extension is only loaded once.
+	ResolveExtensions []string
+	// CacheDir, if set, is a directory where YAML documents are stored once
+	// parsed, so that later processes can skip parsing them while their
+	// content is unchanged. Only the documents that the loader reads as a
+	// DocumentLoader are stored, and a stored document is only used once the
+	// loader has resolved the URI to it, so its policy still applies. JSON
+	// documents are not stored, since reading them back would save nothing;
+	// neither are remote ones, see HTTPLoader.CacheDir for those.
+	CacheDir string
+
+	loader Loader
+
+	mu    sync.Mutex
+	cache map[string]*Schema
+	calls map[string]*cachedCall
+}
+
+// cachedCall is a load in progress, which is done once done is closed.
+type cachedCall struct {
+	done   chan struct{}
+	schema *Schema
+	err    error
+}
+
+func (l *CachedLoader) Load(uri, parentURI string) (*Schema, error) {
+	return l.LoadContext(context.Background(), uri, parentURI)
+}
+
+func (l *CachedLoader) LoadContext(ctx context.Context, uri, parentURI string) (*Schema, error) {
+	key := l.Key(uri, parentURI)
+
+	for {
+		l.mu.Lock()
+
+		if schema, ok := l.cache[key]; ok {
+			l.mu.Unlock()
+
+			return schema, nil
+		}
+
+		call, ok := l.calls[key]
+		if !ok {
+			call = &cachedCall{done: make(chan struct{})}
+			l.calls[key] = call
+		}
+
+		l.mu.Unlock()
+
+		if !ok {
+			return l.lead(ctx, call, key, uri, parentURI)
+		}
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		// A load given up by the context of another caller is retried.
+		if isContextError(call.err) && ctx.Err() == nil {
+			continue
+		}
+
+		return call.schema, call.err
+	}
+}
+
+// lead makes the call that the other loads of the same key wait for.
+func (l *CachedLoader) localFiles(uri, parentURI string) []string {
+	return localFiles(l.loader, uri, parentURI)
+}
+
+func (l *CachedLoader) lead(ctx context.Context, call *cachedCall, key, uri, parentURI string) (*Schema, error) {
+	call.schema, call.err = l.load(ctx, uri, parentURI)
+
+	l.mu.Lock()
+
+	if call.err == nil {
+		l.cache[key] = call.schema
+	}
+
+	delete(l.calls, key)
+	l.mu.Unlock()
+
+	close(call.done)
+
+	return call.schema, call.err
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// Key returns the canonical URI that the schema at uri is cached by: the
+// name of the document of a file, as the loader resolves it if it is a
+// DocumentLoader, or the URI without its fragment, resolved against
+// parentURI.
+func (l *CachedLoader) Key(uri, parentURI string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+
+	if ref, err := GetRefType(parentURI); err == nil && ref != RefTypeFile && !u.IsAbs() {
+		if base, err := url.Parse(parentURI); err == nil {
+			u = base.ResolveReference(u)
+		}
+	}
+
+	if ref, err := GetRefType(u.String()); err == nil && ref == RefTypeFile {
+		if loader, ok := l.loader.(DocumentLoader); ok {
+			if name, err := loader.ResolveDocument(uri, parentURI); err == nil {
+				return name
+			}
+		} else if qualified, err := QualifiedFileName(uri, parentURI, l.ResolveExtensions); err == nil {
+			if abs, err := filepath.Abs(qualified); err == nil {
+				return abs
+			}
+
+			return qualified
+		}
+
+		// The file may be in a file system other than the local one.
+		if !path.IsAbs(uri) {
+			return path.Join(path.Dir(parentURI), uri)
+		}
+
+		return uri
+	}
+
+	u.Fragment = ""
+
+	return u.String()
+}
+
+func (l *CachedLoader) load(ctx context.Context, uri, parentURI string) (*Schema, error) {
+	name, data := l.document(uri, parentURI)
+
+	if data != nil {
+		if schema := l.stored(name, data); schema != nil {
+			return schema, nil
+		}
+	}
+
+	schema, err := LoadContext(ctx, l.loader, uri, parentURI)
+	if err != nil {
+		return nil, errors.Join(ErrCannotLoadSchema, err)
+	}
+
+	if data != nil {
+		if err := l.store(name, data, schema); err != nil {
+			return nil, err
+		}
+	}
+
+	return schema, nil
+}
+
+// document returns the name and content of the document of uri, if it is
+// to be stored in the cache directory.
+func (l *CachedLoader) document(uri, parentURI string) (string, []byte) {
+	loader, ok := l.loader.(DocumentLoader)
+	if !ok || l.CacheDir == "" {
+		return "", nil
+	}
+
+	name, err := loader.ResolveDocument(uri, parentURI)
+	if err != nil {
+		return "", nil
+	}
+
+	data, err := loader.ReadDocument(name)
+	if err != nil || IsJSON(data) {
+		return "", nil
+	}
+
+	return name, data
+}
+
+// storedDocument is a parsed document that a CachedLoader stores as JSON in
+// a file named after the hash of its key.
+type storedDocument struct {
+	Key string `json:"key"`
+	// Hash is the SHA-256 of the content that the document was parsed from.
+	Hash      string            `json:"hash"`
+	File      string            `json:"file"`
+	Document  json.RawMessage   `json:"document"`
+	Positions map[string][2]int `json:"positions"`
+}
+
+func (l *CachedLoader) storedFile(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(l.CacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// stored returns the stored document of key, if it was parsed from data.
+// Unreadable documents are ignored, so that they are parsed and stored again.
+func (l *CachedLoader) stored(key string, data []byte) *Schema {
+	content, err := os.ReadFile(l.storedFile(key))
+	if err != nil {
+		return nil
+	}
+
+	var doc storedDocument
+	if err := json.Unmarshal(content, &doc); err != nil || doc.Key != key || doc.Hash != contentHash(data) {
+		return nil
+	}
+
+	schema, err := FromJSONReader(bytes.NewReader(doc.Document))
+	if err != nil {
+		return nil
+	}
+
+	schema.setSource(doc.File, doc.Positions)
+
+	return schema
+}
+
+// store stores the document of a schema parsed from data, unless it was not
+// parsed from a document.
+func (l *CachedLoader) store(key string, data []byte, schema *Schema) error {
+	if schema.document == nil || schema.source == nil {
+		return nil
+	}
+
+	content, err := json.Marshal(storedDocument{
+		Key:       key,
+		Hash:      contentHash(data),
+		File:      schema.source.file,
+		Document:  schema.document,
+		Positions: schema.source.positions,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode cached document: %w", err)
+	}
+
+	return writeCacheFile(l.CacheDir, l.storedFile(key), content)
+}
+
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// writeCacheFile writes a file of a cache directory through a temporary
+// file, so that concurrent readers never see a partial file.
+func writeCacheFile(dir, fileName string, content []byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), fileName); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package schemas
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errNotLoaded = errors.New("not loaded")
+
+// countingLoader counts the loads of an inner loader, which it starts once
+// release is closed.
+type countingLoader struct {
+	loader  Loader
+	release chan struct{}
+	loads   atomic.Int32
+}
+
+func (l *countingLoader) Load(uri, parentURI string) (*Schema, error) {
+	l.loads.Add(1)
+
+	if l.release != nil {
+		<-l.release
+	}
+
+	if l.loader == nil {
+		return nil, errNotLoaded
+	}
+
+	return l.loader.Load(uri, parentURI)
+}
+
+func TestCachedLoaderKeys(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"title": "a"}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "a.json"), []byte(`{"title": "nested a"}`), 0o644))
+
+	extensions := []string{".json"}
+	inner := &countingLoader{loader: NewFileLoader(extensions, nil)}
+	loader := NewCachedLoader(inner, map[string]*Schema{})
+	loader.ResolveExtensions = extensions
+
+	parent := filepath.Join(dir, "root.json")
+
+	a, err := loader.Load(filepath.Join(dir, "a.json"), "")
+	require.NoError(t, err)
+
+	for _, uri := range []string{"a", "a.json", "./nested/../a.json", "file://" + filepath.Join(dir, "a.json")} {
+		got, err := loader.Load(uri, parent)
+		require.NoError(t, err)
+		assert.Same(t, a, got, uri)
+	}
+
+	nested, err := loader.Load("a.json", filepath.Join(dir, "nested", "root.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "nested a", nested.Title)
+
+	assert.Equal(t, int32(2), inner.loads.Load())
+
+	assert.Equal(t, "https://example.com/b.json", loader.Key("b.json#/$defs/x", "https://example.com/a.json"))
+	assert.Equal(t, "vendor/b.json", loader.Key("../b.json", "vendor/nested/a.json"))
+}
+
+func TestCachedLoaderConcurrent(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.RegisterBytes([]byte(`{"$id": "urn:example:a", "title": "a"}`)))
+
+	inner := &countingLoader{loader: registry, release: make(chan struct{})}
+	loader := NewCachedLoader(inner, map[string]*Schema{})
+
+	var wg sync.WaitGroup
+
+	results := make([]*Schema, 10)
+
+	for i := range results {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			schema, err := loader.Load("urn:example:a", "")
+			assert.NoError(t, err)
+
+			results[i] = schema
+		}()
+	}
+
+	close(inner.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), inner.loads.Load())
+
+	for _, schema := range results {
+		assert.Same(t, results[0], schema)
+	}
+}
+
+// documentCountingLoader reads documents from files, and counts its loads
+// instead of making them.
+type documentCountingLoader struct {
+	*FileLoader
+	loads atomic.Int32
+}
+
+func (l *documentCountingLoader) Load(string, string) (*Schema, error) {
+	l.loads.Add(1)
+
+	return nil, errNotLoaded
+}
+
+func TestCachedLoaderPersists(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+	fileName := filepath.Join(dir, "a.yaml")
+
+	require.NoError(t, os.WriteFile(fileName, []byte("title: a\nproperties:\n  b:\n    type: strin\n"), 0o644))
+
+	load := func(inner Loader) (*Schema, error) {
+		t.Helper()
+
+		loader := NewCachedLoader(inner, map[string]*Schema{})
+		loader.CacheDir = cacheDir
+
+		return loader.Load(fileName, "")
+	}
+
+	schema, err := load(NewFileLoader(nil, []string{".yaml"}))
+	require.NoError(t, err)
+	assert.Equal(t, "a", schema.Title)
+
+	// The stored document is used while the file is unchanged.
+	unused := &documentCountingLoader{FileLoader: NewFileLoader(nil, []string{".yaml"})}
+
+	schema, err = load(unused)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), unused.loads.Load())
+	assert.Equal(t, "a", schema.Title)
+	assert.Equal(t, Origin{File: fileName, Pointer: "/properties/b", Line: 3, Column: 3},
+		schema.Properties["b"].Origin())
+	require.ErrorIs(t, schema.ValidateMeta(), ErrInvalidSchema)
+
+	// It is only used once the loader resolved the URI to it.
+	_, err = load(NewPolicyLoader(NewFileLoader(nil, []string{".yaml"}), Policy{Roots: []string{cacheDir}}, nil))
+	require.ErrorIs(t, err, ErrOutsideRoots)
+
+	_, err = load(&countingLoader{})
+	require.ErrorIs(t, err, errNotLoaded)
+
+	require.NoError(t, os.WriteFile(fileName, []byte("title: changed\n"), 0o644))
+
+	_, err = load(unused)
+	require.ErrorIs(t, err, errNotLoaded)
+	assert.Equal(t, int32(1), unused.loads.Load())
+}
+
+func TestCachedLoaderDoesNotStoreJSON(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+	fileName := filepath.Join(dir, "a.json")
+
+	require.NoError(t, os.WriteFile(fileName, []byte(`{"title": "a"}`), 0o644))
+
+	loader := NewCachedLoader(NewFileLoader(nil, nil), map[string]*Schema{})
+	loader.CacheDir = cacheDir
+
+	schema, err := loader.Load(fileName, "")
+	require.NoError(t, err)
+	assert.Equal(t, "a", schema.Title)
+	assert.NoDirExists(t, cacheDir)
+}
+
+func TestCachedLoaderKeysOfFSLoader(t *testing.T) {
+	loader := NewCachedLoader(NewFSLoader(catalog, []string{".json"}, nil), map[string]*Schema{})
+
+	// Names are resolved within the file system, not against the working
+	// directory.
+	assert.Equal(t, "vendor/a.json", loader.Key("a", "vendor/nested/../b.json"))
+	assert.Equal(t, "vendor/a.json", loader.Key("/vendor/a", ""))
+}
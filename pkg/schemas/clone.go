@@ -0,0 +1,96 @@
+package schemas
+
+import (
+	"maps"
+	"slices"
+)
+
+// Clone returns a deep copy of the schema, so that a generator can prepare
+// and annotate its types without changing those that a loader shares with
+// other generators. Types that the schema refers to more than once are
+// copied once. The document and source it was parsed from are shared, since
+// they are never changed.
+func (s *Schema) Clone() *Schema {
+	c := &cloner{types: map[*Type]*Type{}}
+
+	clone := *s
+	clone.ObjectAsType = (*ObjectAsType)(c.clone((*Type)(s.ObjectAsType)))
+	clone.Definitions = c.cloneMap(s.Definitions)
+	clone.warnings = slices.Clone(s.warnings)
+
+	return &clone
+}
+
+type cloner struct {
+	types map[*Type]*Type
+}
+
+func (c *cloner) clone(t *Type) *Type {
+	if t == nil {
+		return nil
+	}
+
+	if clone, ok := c.types[t]; ok {
+		return clone
+	}
+
+	clone := *t
+	c.types[t] = &clone
+
+	clone.Required = slices.Clone(t.Required)
+	clone.Type = slices.Clone(t.Type)
+	clone.Enum = slices.Clone(t.Enum)
+	clone.DependentRequired = maps.Clone(t.DependentRequired)
+
+	clone.Properties = c.cloneMap(t.Properties)
+	clone.PatternProperties = c.cloneMap(t.PatternProperties)
+	clone.DependentSchemas = c.cloneMap(t.DependentSchemas)
+	clone.Definitions = c.cloneMap(t.Definitions)
+
+	clone.AllOf = c.cloneSlice(t.AllOf)
+	clone.AnyOf = c.cloneSlice(t.AnyOf)
+	clone.OneOf = c.cloneSlice(t.OneOf)
+	clone.PrefixItems = c.cloneSlice(t.PrefixItems)
+	clone.conditions = c.cloneSlice(t.conditions)
+
+	for _, field := range []**Type{
+		&clone.AdditionalItems, &clone.Items, &clone.AdditionalProperties, &clone.Not, &clone.If,
+		&clone.Then, &clone.Else, &clone.Media, &clone.Contains, &clone.PropertyNames,
+		&clone.UnevaluatedItems, &clone.UnevaluatedProperties, &clone.oneOfParent,
+	} {
+		*field = c.clone(*field)
+	}
+
+	if t.sharedAttribute != nil {
+		shared := *t.sharedAttribute
+		clone.sharedAttribute = &shared
+	}
+
+	return &clone
+}
+
+func (c *cloner) cloneMap(types map[string]*Type) map[string]*Type {
+	if types == nil {
+		return nil
+	}
+
+	clones := make(map[string]*Type, len(types))
+	for name, t := range types {
+		clones[name] = c.clone(t)
+	}
+
+	return clones
+}
+
+func (c *cloner) cloneSlice(types []*Type) []*Type {
+	if types == nil {
+		return nil
+	}
+
+	clones := make([]*Type, len(types))
+	for i, t := range types {
+		clones[i] = c.clone(t)
+	}
+
+	return clones
+}
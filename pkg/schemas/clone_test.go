@@ -0,0 +1,33 @@
+package schemas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClone(t *testing.T) {
+	schema, err := FromBytes([]byte(`{
+		"type": "object",
+		"properties": {"a": {"$ref": "#/$defs/a"}},
+		"if": {"properties": {"a": {"const": "x"}}},
+		"then": {"properties": {"b": {"type": "string"}}},
+		"$defs": {"a": {"type": "string"}}
+	}`))
+	require.NoError(t, err)
+
+	schema.SetSource("schema.json", nil)
+
+	clone := schema.Clone()
+	require.Equal(t, schema.Properties["a"].Ref, clone.Properties["a"].Ref)
+
+	clone.Properties["b"] = clone.Then.Properties["b"]
+	clone.Definitions["a"].Type = TypeList{"integer"}
+	clone.Then.Properties["b"].MaxLength = 3
+
+	assert.Len(t, schema.Properties, 1)
+	assert.Equal(t, TypeList{"string"}, schema.Definitions["a"].Type)
+	assert.Zero(t, schema.Then.Properties["b"].MaxLength)
+	assert.Equal(t, schema.Definitions["a"].Origin(), clone.Definitions["a"].Origin())
+}
@@ -0,0 +1,119 @@
+package schemas
+
+import (
+	"strings"
+)
+
+// Dialect is a version of JSON schema, as identified by the $schema keyword.
+type Dialect string
+
+const (
+	DialectUnknown     Dialect = ""
+	DialectDraft04     Dialect = "draft-04"
+	DialectDraft06     Dialect = "draft-06"
+	DialectDraft07     Dialect = "draft-07"
+	DialectDraft201909 Dialect = "draft 2019-09"
+	DialectDraft202012 Dialect = "draft 2020-12"
+)
+
+// dialects are the known dialects, oldest first.
+var dialects = []Dialect{
+	DialectDraft04,
+	DialectDraft06,
+	DialectDraft07,
+	DialectDraft201909,
+	DialectDraft202012,
+}
+
+var metaSchemaDialects = map[string]Dialect{
+	"json-schema.org/draft-04/schema":      DialectDraft04,
+	"json-schema.org/draft-06/schema":      DialectDraft06,
+	"json-schema.org/draft-07/schema":      DialectDraft07,
+	"json-schema.org/draft/2019-09/schema": DialectDraft201909,
+	"json-schema.org/draft/2020-12/schema": DialectDraft202012,
+}
+
+// DetectDialect returns the dialect of the meta-schema URI given by $schema,
+// which may use either http or https and may end with an empty fragment.
+func DetectDialect(uri string) Dialect {
+	uri = strings.TrimSuffix(uri, "#")
+	uri = strings.TrimPrefix(strings.TrimPrefix(uri, "http://"), "https://")
+
+	return metaSchemaDialects[uri]
+}
+
+// Dialect returns the dialect declared by the $schema of the document.
+func (s *Schema) Dialect() Dialect {
+	if s.ObjectAsType == nil {
+		return DialectUnknown
+	}
+
+	return DetectDialect(s.Version)
+}
+
+// keywordDialects are the first and last dialects of the keywords that some
+// dialects do not have; an empty bound is open.
+var keywordDialects = map[string]struct{ since, until Dialect }{
+	"id":                    {until: DialectDraft04},
+	"$id":                   {since: DialectDraft06},
+	"const":                 {since: DialectDraft06},
+	"contains":              {since: DialectDraft06},
+	"propertyNames":         {since: DialectDraft06},
+	"examples":              {since: DialectDraft06},
+	"if":                    {since: DialectDraft07},
+	"then":                  {since: DialectDraft07},
+	"else":                  {since: DialectDraft07},
+	"$comment":              {since: DialectDraft07},
+	"readOnly":              {since: DialectDraft07},
+	"writeOnly":             {since: DialectDraft07},
+	"contentMediaType":      {since: DialectDraft07},
+	"contentEncoding":       {since: DialectDraft07},
+	"dependencies":          {until: DialectDraft07},
+	"$anchor":               {since: DialectDraft201909},
+	"$defs":                 {since: DialectDraft201909},
+	"$vocabulary":           {since: DialectDraft201909},
+	"dependentRequired":     {since: DialectDraft201909},
+	"dependentSchemas":      {since: DialectDraft201909},
+	"maxContains":           {since: DialectDraft201909},
+	"minContains":           {since: DialectDraft201909},
+	"unevaluatedItems":      {since: DialectDraft201909},
+	"unevaluatedProperties": {since: DialectDraft201909},
+	"deprecated":            {since: DialectDraft201909},
+	"contentSchema":         {since: DialectDraft201909},
+	"$recursiveRef":         {since: DialectDraft201909, until: DialectDraft201909},
+	"$recursiveAnchor":      {since: DialectDraft201909, until: DialectDraft201909},
+	"additionalItems":       {until: DialectDraft201909},
+	"$dynamicRef":           {since: DialectDraft202012},
+	"$dynamicAnchor":        {since: DialectDraft202012},
+	"prefixItems":           {since: DialectDraft202012},
+}
+
+// keywordDialect returns the nearest dialect that has the keyword, when the
+// given one does not.
+func keywordDialect(keyword string, dialect Dialect) (Dialect, bool) {
+	bounds, ok := keywordDialects[keyword]
+	if !ok || dialect == DialectUnknown {
+		return "", false
+	}
+
+	switch {
+	case bounds.since != "" && dialectRank(dialect) < dialectRank(bounds.since):
+		return bounds.since, true
+
+	case bounds.until != "" && dialectRank(dialect) > dialectRank(bounds.until):
+		return bounds.until, true
+
+	default:
+		return "", false
+	}
+}
+
+func dialectRank(dialect Dialect) int {
+	for i, d := range dialects {
+		if d == dialect {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,94 @@
+package schemas
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// NewFSLoader returns a loader that reads schemas from fsys, such as an
+// embed.FS holding a catalog of schemas. Paths are slash-separated and
+// relative to the root of fsys; relative URIs are resolved against the path
+// of the parent.
+func NewFSLoader(fsys fs.FS, resolveExtensions, yamlExtensions []string) *FSLoader {
+	return &FSLoader{
+		fsys:              fsys,
+		resolveExtensions: resolveExtensions,
+		yamlExtensions:    toExtensionSet(yamlExtensions),
+	}
+}
+
+type FSLoader struct {
+	fsys              fs.FS
+	resolveExtensions []string
+	yamlExtensions    map[string]bool
+}
+
+func (l *FSLoader) Load(uri, parentURI string) (*Schema, error) {
+	name, err := l.ResolveDocument(uri, parentURI)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := l.ReadDocument(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.parse(name, data)
+}
+
+// ResolveDocument returns the path within the file system of the file that
+// uri refers to.
+func (l *FSLoader) ResolveDocument(uri, parentURI string) (string, error) {
+	name := strings.TrimPrefix(uri, "file://")
+	if !path.IsAbs(name) && parentURI != "" {
+		name = path.Join(path.Dir(strings.TrimPrefix(parentURI, "file://")), name)
+	}
+
+	name = strings.TrimPrefix(path.Clean(name), "/")
+
+	if !fs.ValidPath(name) {
+		return "", fmt.Errorf("%w %q", ErrCannotResolveSchema, uri)
+	}
+
+	for _, ext := range append([]string{""}, l.resolveExtensions...) {
+		if info, err := fs.Stat(l.fsys, name+ext); err == nil && !info.IsDir() {
+			return name + ext, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w %q", ErrCannotResolveSchema, name)
+}
+
+func (l *FSLoader) ReadDocument(name string) ([]byte, error) {
+	data, err := fs.ReadFile(l.fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrCannotResolveSchema, name, err)
+	}
+
+	return data, nil
+}
+
+func (l *FSLoader) parse(name string, data []byte) (*Schema, error) {
+	var (
+		schema *Schema
+		err    error
+	)
+
+	if l.yamlExtensions[path.Ext(name)] {
+		schema, err = FromYAMLReader(bytes.NewReader(data))
+	} else {
+		schema, err = FromJSONReader(bytes.NewReader(data))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing file %s: %w", name, err)
+	}
+
+	schema.SetSource(name, data)
+
+	return schema, nil
+}
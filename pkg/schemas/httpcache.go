@@ -0,0 +1,83 @@
+package schemas
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// httpCacheEntry is a response cached by an HTTPLoader, stored as JSON in a
+// file named after the hash of its URL.
+type httpCacheEntry struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	ContentType  string `json:"contentType,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+func (e *httpCacheEntry) setConditions(req *http.Request) {
+	if e.ETag != "" {
+		req.Header.Set("If-None-Match", e.ETag)
+	}
+
+	if e.LastModified != "" {
+		req.Header.Set("If-Modified-Since", e.LastModified)
+	}
+}
+
+func (l *HTTPLoader) cacheFile(uri string) string {
+	sum := sha256.Sum256([]byte(uri))
+
+	return filepath.Join(l.CacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// cached returns the cached response for uri, if any. Unreadable entries are
+// ignored, so that they are fetched and stored again.
+func (l *HTTPLoader) cached(uri string) *httpCacheEntry {
+	if l.CacheDir == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(l.cacheFile(uri))
+	if err != nil {
+		return nil
+	}
+
+	var entry httpCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != uri {
+		return nil
+	}
+
+	return &entry
+}
+
+// store caches a response that can be revalidated.
+func (l *HTTPLoader) store(uri string, header http.Header, body []byte) error {
+	if l.CacheDir == "" {
+		return nil
+	}
+
+	entry := httpCacheEntry{
+		URL:          uri,
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		ContentType:  header.Get("Content-Type"),
+		Body:         body,
+	}
+
+	if entry.ETag == "" && entry.LastModified == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	return writeCacheFile(l.CacheDir, l.cacheFile(uri), data)
+}
@@ -0,0 +1,192 @@
+package schemas
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPLoader(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/schema.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"title": "json"}`))
+	})
+	mux.HandleFunc("/schema", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml; charset=utf-8")
+		_, _ = w.Write([]byte("title: yaml\n"))
+	})
+	mux.HandleFunc("/private.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("X-Client") != "schema2go" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"title": "private"}`))
+	})
+	mux.HandleFunc("/slow.json", func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	mux.HandleFunc("/large.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"title": "` + strings.Repeat("x", 100) + `"}`))
+	})
+	mux.HandleFunc("/redirect/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, strings.TrimPrefix(r.URL.Path, "/redirect"), http.StatusFound)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	newLoader := func() *HTTPLoader {
+		loader := NewHTTPLoader(nil)
+		loader.Client = server.Client()
+
+		return loader
+	}
+
+	testCases := []struct {
+		desc      string
+		path      string
+		configure func(l *HTTPLoader)
+		wantTitle string
+		wantErr   error
+	}{
+		{desc: "json", path: "/schema.json#/$defs/a", wantTitle: "json"},
+		{desc: "yaml content type", path: "/schema", wantTitle: "yaml"},
+		{desc: "missing headers", path: "/private.json", wantErr: ErrUnexpectedStatus},
+		{
+			desc: "headers",
+			path: "/private.json",
+			configure: func(l *HTTPLoader) {
+				l.Headers = http.Header{"X-Client": {"schema2go"}, "Authorization": {"Bearer public"}}
+				l.Hosts = map[string]HTTPHostOptions{host: {Headers: http.Header{"Authorization": {"Bearer secret"}}}}
+			},
+			wantTitle: "private",
+		},
+		{desc: "not found", path: "/missing.json", wantErr: ErrUnexpectedStatus},
+		{
+			desc: "host timeout",
+			path: "/slow.json",
+			configure: func(l *HTTPLoader) {
+				l.Hosts = map[string]HTTPHostOptions{host: {Timeout: 10 * time.Millisecond}}
+			},
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			desc:      "size limit",
+			path:      "/large.json",
+			configure: func(l *HTTPLoader) { l.MaxBytes = 100 },
+			wantErr:   ErrSchemaTooLarge,
+		},
+		{
+			desc:      "redirects",
+			path:      "/redirect/redirect/schema.json",
+			configure: func(l *HTTPLoader) { l.MaxRedirects = 2 },
+			wantTitle: "json",
+		},
+		{
+			desc:      "too many redirects",
+			path:      "/redirect/redirect/schema.json",
+			configure: func(l *HTTPLoader) { l.MaxRedirects = 1 },
+			wantErr:   ErrTooManyRedirects,
+		},
+		{
+			desc:      "no redirects",
+			path:      "/redirect/schema.json",
+			configure: func(l *HTTPLoader) { l.MaxRedirects = -1 },
+			wantErr:   ErrTooManyRedirects,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			loader := newLoader()
+			if tC.configure != nil {
+				tC.configure(loader)
+			}
+
+			got, err := loader.Load(server.URL+tC.path, "")
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.wantTitle, got.Title)
+		})
+	}
+}
+
+func TestHTTPLoaderContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	loader := MultiLoader{RefTypeHTTP: NewHTTPLoader(nil)}
+
+	_, err := LoadContext(ctx, NewCachedLoader(loader, map[string]*Schema{}), server.URL+"/schema.json", "")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestHTTPLoaderCache(t *testing.T) {
+	var (
+		etag     = `"v1"`
+		body     = `{"title": "v1"}`
+		statuses []int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			statuses = append(statuses, http.StatusNotModified)
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		statuses = append(statuses, http.StatusOK)
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	uri := server.URL + "/schema.json"
+
+	load := func() *Schema {
+		t.Helper()
+
+		loader := NewHTTPLoader(nil)
+		loader.CacheDir = cacheDir
+
+		schema, err := loader.Load(uri, "")
+		require.NoError(t, err)
+
+		return schema
+	}
+
+	assert.Equal(t, "v1", load().Title)
+	assert.Equal(t, "v1", load().Title)
+
+	etag, body = `"v2"`, `{"title": "v2"}`
+
+	assert.Equal(t, "v2", load().Title)
+	assert.Equal(t, []int{http.StatusOK, http.StatusNotModified, http.StatusOK}, statuses)
+
+	cached := (&HTTPLoader{CacheDir: cacheDir}).cached(uri)
+	require.NotNil(t, cached)
+	assert.Equal(t, `"v2"`, cached.ETag)
+}
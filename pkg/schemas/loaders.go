@@ -1,15 +1,22 @@
 package schemas
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 var (
@@ -18,37 +25,69 @@ var (
 	ErrUnsupportedContentType   = errors.New("unsupported content type")
 	ErrUnsupportedFileExtension = errors.New("unsupported file extension")
 	ErrUnsupportedURL           = errors.New("unsupported URL")
+	ErrUnexpectedStatus         = errors.New("unexpected HTTP status")
+	ErrTooManyRedirects         = errors.New("too many redirects")
+	ErrSchemaTooLarge           = errors.New("schema is too large")
 )
 
 type Loader interface {
 	Load(uri, parentURI string) (*Schema, error)
 }
 
-func NewCachedLoader(loader Loader, cache map[string]*Schema) *CachedLoader {
-	return &CachedLoader{
-		loader: loader,
-		cache:  cache,
-	}
+// ContextLoader is a Loader that can give up when a context is done, such as
+// one that fetches schemas over the network.
+type ContextLoader interface {
+	Loader
+	LoadContext(ctx context.Context, uri, parentURI string) (*Schema, error)
+}
+
+// DocumentLoader is a Loader that can tell which document it would parse a
+// URI from, and read it, without parsing it, so that a CachedLoader can store
+// the parsed document. ResolveDocument fails for the URIs that the loader
+// would refuse or could not load, and for those it loads without reading a
+// document, such as the schemas of a registry.
+type DocumentLoader interface {
+	Loader
+	// ResolveDocument returns the canonical name of the document of uri,
+	// such as the absolute name of a local file.
+	ResolveDocument(uri, parentURI string) (string, error)
+	// ReadDocument reads a document named by ResolveDocument.
+	ReadDocument(name string) ([]byte, error)
 }
 
-type CachedLoader struct {
-	loader Loader
-	cache  map[string]*Schema
+// errNoDocument is the error of loaders that do not read the documents of
+// some or all URIs themselves.
+var errNoDocument = errors.New("no document to read")
+
+// fileLocator is implemented by the loaders that read some URIs from local
+// files, so that a TrackingLoader can watch the files a URI may be read from,
+// whether they exist yet or not.
+type fileLocator interface {
+	localFiles(uri, parentURI string) []string
 }
 
-func (l *CachedLoader) Load(uri, parentURI string) (*Schema, error) {
-	if schema, ok := l.cache[uri]; ok {
-		return schema, nil
+// localFiles returns the local files that loader may read uri from, if it is
+// a fileLocator.
+func localFiles(loader Loader, uri, parentURI string) []string {
+	if locator, ok := loader.(fileLocator); ok {
+		return locator.localFiles(uri, parentURI)
 	}
 
-	schema, err := l.loader.Load(uri, parentURI)
-	if err != nil {
-		return nil, errors.Join(ErrCannotLoadSchema, err)
+	return nil
+}
+
+// LoadContext loads uri through loader, passing ctx on if the loader is a
+// ContextLoader. Other loaders are only called if ctx is not done yet.
+func LoadContext(ctx context.Context, loader Loader, uri, parentURI string) (*Schema, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	l.cache[uri] = schema
+	if l, ok := loader.(ContextLoader); ok {
+		return l.LoadContext(ctx, uri, parentURI)
+	}
 
-	return schema, nil
+	return loader.Load(uri, parentURI)
 }
 
 func NewFileLoader(resolveExtensions, yamlExtensions []string) *FileLoader {
@@ -77,6 +116,32 @@ func (l *FileLoader) Load(fileName, parentFileName string) (*Schema, error) {
 	return schema, nil
 }
 
+func (l *FileLoader) ResolveDocument(fileName, parentFileName string) (string, error) {
+	qualified, err := QualifiedFileName(fileName, parentFileName, l.resolveExtensions)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Abs(qualified)
+}
+
+func (l *FileLoader) ReadDocument(name string) ([]byte, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCannotResolveSchema, err)
+	}
+
+	return data, nil
+}
+
+func (l *FileLoader) localFiles(fileName, parentFileName string) []string {
+	if ref, err := GetRefType(fileName); err != nil || ref != RefTypeFile {
+		return nil
+	}
+
+	return candidateFileNames(fileName, parentFileName, l.resolveExtensions)
+}
+
 func (l *FileLoader) parseFile(fileName string) (*Schema, error) {
 	if l.yamlExtensions[path.Ext(fileName)] {
 		sc, err := FromYAMLFile(fileName)
@@ -96,7 +161,10 @@ func (l *FileLoader) parseFile(fileName string) (*Schema, error) {
 }
 
 func NewDefaultCacheLoader(resolveExtensions, yamlExtensions []string) *CachedLoader {
-	return NewCachedLoader(NewDefaultMultiLoader(resolveExtensions, yamlExtensions), map[string]*Schema{})
+	loader := NewCachedLoader(NewDefaultMultiLoader(resolveExtensions, yamlExtensions), map[string]*Schema{})
+	loader.ResolveExtensions = resolveExtensions
+
+	return loader
 }
 
 func NewDefaultMultiLoader(resolveExtensions, yamlExtensions []string) MultiLoader {
@@ -112,6 +180,10 @@ func NewDefaultMultiLoader(resolveExtensions, yamlExtensions []string) MultiLoad
 type MultiLoader map[RefType]Loader
 
 func (l MultiLoader) Load(uri, parentURI string) (*Schema, error) {
+	return l.LoadContext(context.Background(), uri, parentURI)
+}
+
+func (l MultiLoader) LoadContext(ctx context.Context, uri, parentURI string) (*Schema, error) {
 	ref, err := GetRefType(uri)
 	if err != nil {
 		return nil, err
@@ -122,7 +194,7 @@ func (l MultiLoader) Load(uri, parentURI string) (*Schema, error) {
 		return nil, ErrUnsupportedRefFormat
 	}
 
-	schema, err := loader.Load(uri, parentURI)
+	schema, err := LoadContext(ctx, loader, uri, parentURI)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load schema %q: %w", uri, err)
 	}
@@ -130,54 +202,296 @@ func (l MultiLoader) Load(uri, parentURI string) (*Schema, error) {
 	return schema, nil
 }
 
+func (l MultiLoader) ResolveDocument(uri, parentURI string) (string, error) {
+	loader, err := l.documentLoader(uri)
+	if err != nil {
+		return "", err
+	}
+
+	return loader.ResolveDocument(uri, parentURI)
+}
+
+func (l MultiLoader) ReadDocument(name string) ([]byte, error) {
+	loader, err := l.documentLoader(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return loader.ReadDocument(name)
+}
+
+func (l MultiLoader) localFiles(uri, parentURI string) []string {
+	ref, err := GetRefType(uri)
+	if err != nil {
+		return nil
+	}
+
+	return localFiles(l[ref], uri, parentURI)
+}
+
+// documentLoader returns the loader of uri, if it is a DocumentLoader.
+func (l MultiLoader) documentLoader(uri string) (DocumentLoader, error) {
+	ref, err := GetRefType(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	loader, ok := l[ref].(DocumentLoader)
+	if !ok {
+		return nil, errNoDocument
+	}
+
+	return loader, nil
+}
+
+// The defaults of the loaders that NewHTTPLoader returns.
+const (
+	DefaultHTTPTimeout  = time.Minute
+	DefaultHTTPMaxBytes = 32 << 20
+)
+
 func NewHTTPLoader(yamlExtensions []string) *HTTPLoader {
-	return &HTTPLoader{YAMLExtensions: toExtensionSet(yamlExtensions)}
+	return &HTTPLoader{
+		YAMLExtensions: toExtensionSet(yamlExtensions),
+		Timeout:        DefaultHTTPTimeout,
+		MaxBytes:       DefaultHTTPMaxBytes,
+	}
 }
 
+// HTTPLoader fetches schemas over HTTP and HTTPS.
 type HTTPLoader struct {
 	YAMLExtensions map[string]bool
+
+	// Client sends the requests; nil means a client with default settings.
+	Client *http.Client
+	// Headers are set on every request, such as an Authorization header.
+	Headers http.Header
+	// Timeout limits each request, including reading its body; zero means
+	// no limit.
+	Timeout time.Duration
+	// Hosts overrides the headers and timeout per host, keyed by the host
+	// name with its port, if any, as in URL.Host.
+	Hosts map[string]HTTPHostOptions
+	// MaxRedirects limits the redirects followed: zero leaves it to the
+	// client, and a negative value follows none.
+	MaxRedirects int
+	// CheckRedirect, if set, is called with the URL of every redirect before
+	// it is followed, and stops the request with its error. A PolicyLoader
+	// checks redirects the same way, through the context of its loads.
+	CheckRedirect func(u *url.URL) error
+	// MaxBytes limits the size of a schema; zero means no limit.
+	MaxBytes int64
+	// CacheDir, if set, is a directory where responses are cached by URL.
+	// Cached responses with an ETag or Last-Modified header are revalidated
+	// with a conditional request, and reused if the server reports they
+	// have not been modified.
+	CacheDir string
+}
+
+// HTTPHostOptions are the settings of an HTTPLoader for a single host.
+type HTTPHostOptions struct {
+	// Headers are set on the requests to the host, after and over those of
+	// the loader.
+	Headers http.Header
+	// Timeout replaces the timeout of the loader, if it is not zero.
+	Timeout time.Duration
 }
 
 func (l *HTTPLoader) Load(uri, parentURI string) (*Schema, error) {
+	return l.LoadContext(context.Background(), uri, parentURI)
+}
+
+// LoadContext fetches uri, giving up when ctx is done.
+func (l *HTTPLoader) LoadContext(ctx context.Context, uri, _ string) (*Schema, error) {
 	u, err := url.Parse(uri)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse url: %w", err)
 	}
 
-	if u.Scheme == "http" || u.Scheme == "https" {
-		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, u.String(), nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedURL, uri)
+	}
+
+	u.Fragment = ""
+
+	host := l.Hosts[u.Host]
+
+	timeout := l.Timeout
+	if host.Timeout != 0 {
+		timeout = host.Timeout
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for _, headers := range []http.Header{l.Headers, host.Headers} {
+		for name, values := range headers {
+			req.Header[http.CanonicalHeaderKey(name)] = values
 		}
+	}
 
-		resp, err := (&http.Client{}).Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("failed to perform request: %w", err)
+	cached := l.cached(u.String())
+	if cached != nil {
+		cached.setConditions(req)
+	}
+
+	resp, err := l.client(ctx).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform request: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return l.parse(u, uri, cached.ContentType, cached.Body)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedStatus, resp.Status)
+	}
+
+	data, err := l.readBody(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := l.parse(u, uri, resp.Header.Get("Content-Type"), data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.store(u.String(), resp.Header, data); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// defaultMaxRedirects is the limit of http.Client, which applies when the
+// client checks redirects for a loader without a limit of its own.
+const defaultMaxRedirects = 10
+
+func (l *HTTPLoader) client(ctx context.Context) *http.Client {
+	client := l.Client
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	checks := redirectChecks(ctx)
+	if l.CheckRedirect != nil {
+		checks = append(checks, l.CheckRedirect)
+	}
+
+	if l.MaxRedirects == 0 && len(checks) == 0 {
+		return client
+	}
+
+	limited := *client
+	limited.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		for _, check := range checks {
+			if err := check(req.URL); err != nil {
+				return err
+			}
 		}
 
-		defer func() {
-			if resp != nil && resp.Body != nil {
-				_ = resp.Body.Close()
+		switch {
+		case l.MaxRedirects != 0:
+			if len(via) > l.MaxRedirects {
+				return fmt.Errorf("%w: stopped after %d", ErrTooManyRedirects, max(l.MaxRedirects, 0))
 			}
-		}()
 
-		switch resp.Header.Get("Content-Type") {
-		case "application/json":
-			return FromJSONReader(resp.Body)
+		case client.CheckRedirect != nil:
+			return client.CheckRedirect(req, via)
 
-		case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
-			return FromYAMLReader(resp.Body)
+		case len(via) >= defaultMaxRedirects:
+			return fmt.Errorf("%w: stopped after %d", ErrTooManyRedirects, defaultMaxRedirects)
+		}
 
-		default:
-			if l.YAMLExtensions[path.Ext(u.Path)] {
-				return FromYAMLReader(resp.Body)
-			}
+		return nil
+	}
+
+	return &limited
+}
+
+type redirectChecksKey struct{}
 
-			return FromJSONReader(resp.Body)
+// withRedirectCheck returns a context in which an HTTPLoader calls check
+// before following a redirect, as well as the checks of ctx.
+func withRedirectCheck(ctx context.Context, check func(u *url.URL) error) context.Context {
+	checks := append(redirectChecks(ctx), check)
+
+	return context.WithValue(ctx, redirectChecksKey{}, checks)
+}
+
+func redirectChecks(ctx context.Context) []func(u *url.URL) error {
+	checks, _ := ctx.Value(redirectChecksKey{}).([]func(u *url.URL) error)
+
+	return slices.Clip(checks)
+}
+
+func (l *HTTPLoader) readBody(body io.Reader) ([]byte, error) {
+	if l.MaxBytes <= 0 {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
 		}
+
+		return data, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, l.MaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	return nil, fmt.Errorf("%w: %q", ErrUnsupportedURL, uri)
+	if int64(len(data)) > l.MaxBytes {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrSchemaTooLarge, l.MaxBytes)
+	}
+
+	return data, nil
+}
+
+func (l *HTTPLoader) parse(u *url.URL, uri, contentType string, data []byte) (*Schema, error) {
+	var (
+		schema *Schema
+		err    error
+	)
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch mediaType {
+	case "application/json", "application/schema+json":
+		schema, err = FromJSONReader(bytes.NewReader(data))
+
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		schema, err = FromYAMLReader(bytes.NewReader(data))
+
+	default:
+		if l.YAMLExtensions[path.Ext(u.Path)] {
+			schema, err = FromYAMLReader(bytes.NewReader(data))
+		} else {
+			schema, err = FromJSONReader(bytes.NewReader(data))
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	schema.SetSource(uri, data)
+
+	return schema, nil
 }
 
 func QualifiedFileName(fileName, parentFileName string, resolveExtensions []string) (string, error) {
@@ -187,19 +501,16 @@ func QualifiedFileName(fileName, parentFileName string, resolveExtensions []stri
 	}
 
 	if r != RefTypeFile {
-		return fileName[strings.Index(fileName, "://")+3:], nil
-	}
-
-	fileName = strings.TrimPrefix(fileName, "file://")
+		// URIs such as URNs have no authority to strip.
+		if i := strings.Index(fileName, "://"); i >= 0 {
+			return fileName[i+3:], nil
+		}
 
-	if !filepath.IsAbs(fileName) {
-		fileName = filepath.Join(filepath.Dir(parentFileName), fileName)
+		return fileName, nil
 	}
 
-	exts := append([]string{""}, resolveExtensions...)
-	for _, ext := range exts {
-		qualified := fileName + ext
-
+	candidates := candidateFileNames(fileName, parentFileName, resolveExtensions)
+	for _, qualified := range candidates {
 		if !fileExists(qualified) {
 			continue
 		}
@@ -214,7 +525,25 @@ func QualifiedFileName(fileName, parentFileName string, resolveExtensions []stri
 		return qualified, nil
 	}
 
-	return "", fmt.Errorf("%w %q", ErrCannotResolveSchema, fileName)
+	return "", fmt.Errorf("%w %q", ErrCannotResolveSchema, candidates[0])
+}
+
+// candidateFileNames returns the names that QualifiedFileName tries for a
+// file ref, in order: the name resolved against the parent, then the name
+// with each of the extensions.
+func candidateFileNames(fileName, parentFileName string, resolveExtensions []string) []string {
+	fileName = strings.TrimPrefix(fileName, "file://")
+
+	if !filepath.IsAbs(fileName) {
+		fileName = filepath.Join(filepath.Dir(parentFileName), fileName)
+	}
+
+	candidates := []string{fileName}
+	for _, ext := range resolveExtensions {
+		candidates = append(candidates, fileName+ext)
+	}
+
+	return candidates
 }
 
 func fileExists(fileName string) bool {
@@ -236,3 +565,62 @@ func toExtensionSet(items []string) map[string]bool {
 
 	return set
 }
+
+// NewTrackingLoader returns a loader that records the names of the local files
+// that every schema loaded through it, including transitive $ref targets, may
+// be read from: those of mirrored URIs as well, and also the ones that do not
+// exist, so that creating one can be noticed.
+func NewTrackingLoader(loader Loader, resolveExtensions []string) *TrackingLoader {
+	return &TrackingLoader{
+		loader:            loader,
+		resolveExtensions: resolveExtensions,
+		files:             map[string]struct{}{},
+	}
+}
+
+type TrackingLoader struct {
+	loader            Loader
+	resolveExtensions []string
+
+	mu    sync.Mutex
+	files map[string]struct{}
+}
+
+func (l *TrackingLoader) Load(uri, parentURI string) (*Schema, error) {
+	return l.LoadContext(context.Background(), uri, parentURI)
+}
+
+func (l *TrackingLoader) LoadContext(ctx context.Context, uri, parentURI string) (*Schema, error) {
+	files := localFiles(l.loader, uri, parentURI)
+
+	// Loaders that cannot tell read file refs the way a FileLoader does.
+	if _, ok := l.loader.(fileLocator); !ok {
+		if ref, err := GetRefType(uri); err == nil && ref == RefTypeFile {
+			files = candidateFileNames(uri, parentURI, l.resolveExtensions)
+		}
+	}
+
+	l.mu.Lock()
+	for _, f := range files {
+		l.files[f] = struct{}{}
+	}
+	l.mu.Unlock()
+
+	return LoadContext(ctx, l.loader, uri, parentURI)
+}
+
+// Files returns the sorted names of all local files that the loads so far
+// read or would have read, had they existed.
+func (l *TrackingLoader) Files() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	files := make([]string, 0, len(l.files))
+	for f := range l.files {
+		files = append(files, f)
+	}
+
+	sort.Strings(files)
+
+	return files
+}
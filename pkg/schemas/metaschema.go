@@ -0,0 +1,630 @@
+package schemas
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var ErrInvalidSchema = errors.New("schema does not conform to its meta-schema")
+
+//go:embed metaschemas/*.json
+var metaSchemaFiles embed.FS
+
+var metaSchemaFileNames = map[Dialect]string{
+	DialectDraft04:     "metaschemas/draft-04.json",
+	DialectDraft06:     "metaschemas/draft-06.json",
+	DialectDraft07:     "metaschemas/draft-07.json",
+	DialectDraft201909: "metaschemas/draft-2019-09.json",
+	DialectDraft202012: "metaschemas/draft-2020-12.json",
+}
+
+var (
+	metaSchemasOnce sync.Once
+	metaSchemas     map[Dialect]map[string]any
+	// metaKeywords are the keywords of any dialect, which are never taken
+	// for misspellings of another.
+	metaKeywords map[string]struct{}
+)
+
+func loadMetaSchemas() {
+	metaSchemas = map[Dialect]map[string]any{}
+	metaKeywords = map[string]struct{}{}
+
+	for dialect, fileName := range metaSchemaFileNames {
+		data, err := metaSchemaFiles.ReadFile(fileName)
+		if err != nil {
+			panic(err)
+		}
+
+		var meta map[string]any
+		if err := json.Unmarshal(data, &meta); err != nil {
+			panic(fmt.Sprintf("invalid meta-schema %s: %v", fileName, err))
+		}
+
+		metaSchemas[dialect] = meta
+
+		properties, _ := meta["properties"].(map[string]any)
+		for keyword := range properties {
+			metaKeywords[keyword] = struct{}{}
+		}
+	}
+}
+
+// Violation is a place where a document does not conform to the meta-schema
+// of its dialect.
+type Violation struct {
+	Origin
+
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.File == "" {
+		return fmt.Sprintf("#%s: %s", v.Pointer, v.Message)
+	}
+
+	if v.Line == 0 {
+		return fmt.Sprintf("%s#%s: %s", v.File, v.Pointer, v.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", v.Origin, v.Message)
+}
+
+// MetaSchemaError lists the violations of the meta-schema of a document.
+type MetaSchemaError struct {
+	Dialect    Dialect
+	Violations []Violation
+}
+
+func (e *MetaSchemaError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.String())
+	}
+
+	return fmt.Sprintf("%v (%s): %s", ErrInvalidSchema, e.Dialect, strings.Join(messages, "; "))
+}
+
+func (e *MetaSchemaError) Unwrap() error {
+	return ErrInvalidSchema
+}
+
+// ValidateMeta checks the document the schema was parsed from against the
+// meta-schema of its dialect, which is embedded in the package. A document
+// without a known $schema only has to conform to the meta-schema of one of
+// the dialects. It returns a *MetaSchemaError, or nil for a conforming
+// document or a schema that was not parsed.
+func (s *Schema) ValidateMeta() error {
+	_, err := s.LintMeta()
+
+	return err
+}
+
+// LintMeta checks the schema as ValidateMeta does, and also returns the
+// keywords that are not known to any dialect but close to one of its own,
+// as likely misspellings. The meta-schemas allow unknown keywords, so these
+// are not violations.
+func (s *Schema) LintMeta() ([]Violation, error) {
+	if s.document == nil {
+		return nil, nil
+	}
+
+	misspellings, err := validateMeta(s.document, s.Dialect())
+
+	for i := range misspellings {
+		misspellings[i].Origin = s.source.origin(misspellings[i].Pointer)
+	}
+
+	return misspellings, locateViolations(err, s.source)
+}
+
+// validateMeta checks a document against the meta-schema of dialect, or of
+// every dialect if it is unknown, without locating the violations. It
+// returns the misspellings of the keywords of the dialect that the document
+// is checked against.
+func validateMeta(document []byte, dialect Dialect) ([]Violation, error) {
+	var instance any
+	if err := json.Unmarshal(document, &instance); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	metaSchemasOnce.Do(loadMetaSchemas)
+
+	candidates := slices.Clone(dialects)
+	if dialect != DialectUnknown {
+		candidates = []Dialect{dialect}
+	}
+
+	var (
+		best             *MetaSchemaError
+		bestMisspellings []Violation
+	)
+
+	// Prefer the newest dialect among those with the fewest violations.
+	for i := len(candidates) - 1; i >= 0; i-- {
+		mv := &metaValidator{root: metaSchemas[candidates[i]], misspellings: map[string]Violation{}}
+
+		violations := mv.validate(mv.root, instance, "")
+		if len(violations) == 0 {
+			return mv.sortedMisspellings(), nil
+		}
+
+		if best == nil || len(violations) < len(best.Violations) {
+			best = &MetaSchemaError{Dialect: candidates[i], Violations: violations}
+			bestMisspellings = mv.sortedMisspellings()
+		}
+	}
+
+	return bestMisspellings, best
+}
+
+// locateViolations sets the origins of the violations of err, if it wraps a
+// *MetaSchemaError, to where they are in src, and returns that error, since
+// the messages of those that wrap it no longer match it.
+func locateViolations(err error, src *source) error {
+	var metaErr *MetaSchemaError
+	if !errors.As(err, &metaErr) {
+		return err
+	}
+
+	for i := range metaErr.Violations {
+		metaErr.Violations[i].Origin = src.origin(metaErr.Violations[i].Pointer)
+	}
+
+	return metaErr
+}
+
+// metaValidator checks instances against the subset of JSON schema that
+// the embedded meta-schemas use.
+type metaValidator struct {
+	root map[string]any
+	// misspellings are kept by pointer, since the branches of an anyOf may
+	// check the same object.
+	misspellings map[string]Violation
+}
+
+func (v *metaValidator) sortedMisspellings() []Violation {
+	misspellings := make([]Violation, 0, len(v.misspellings))
+	for _, pointer := range sortedKeys(v.misspellings) {
+		misspellings = append(misspellings, v.misspellings[pointer])
+	}
+
+	return misspellings
+}
+
+func (v *metaValidator) validate(schema, instance any, pointer string) []Violation {
+	var violations []Violation
+
+	fail := func(format string, args ...any) {
+		violations = append(violations, Violation{
+			Origin:  Origin{Pointer: pointer},
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	s, ok := schema.(map[string]any)
+	if !ok {
+		if schema == false {
+			fail("is not allowed")
+		}
+
+		return nil
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		violations = append(violations, v.validate(v.resolve(ref), instance, pointer)...)
+	}
+
+	if types, ok := s["type"]; ok && !typeMatches(types, instance) {
+		fail("must be of type %s, not %s", typeNames(types), jsonTypeName(instance))
+
+		return violations
+	}
+
+	if enum, ok := s["enum"].([]any); ok && !slices.ContainsFunc(enum, func(e any) bool {
+		return reflect.DeepEqual(e, instance)
+	}) {
+		fail("must be one of %s%s", formatValues(enum), suggestion(instance, enum))
+	}
+
+	if c, ok := s["const"]; ok && !reflect.DeepEqual(c, instance) {
+		fail("must be %s", formatValues([]any{c}))
+	}
+
+	switch value := instance.(type) {
+	case float64:
+		violations = append(violations, v.validateNumber(s, value, pointer)...)
+
+	case string:
+		if pattern, ok := s["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(value) {
+			fail("must match the pattern %q", pattern)
+		}
+
+	case []any:
+		violations = append(violations, v.validateArray(s, value, pointer)...)
+
+	case map[string]any:
+		violations = append(violations, v.validateObject(s, value, pointer)...)
+	}
+
+	violations = append(violations, v.validateApplicators(s, instance, pointer)...)
+
+	return violations
+}
+
+func (v *metaValidator) validateNumber(s map[string]any, value float64, pointer string) []Violation {
+	var violations []Violation
+
+	fail := func(format string, args ...any) {
+		violations = append(violations, Violation{
+			Origin:  Origin{Pointer: pointer},
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	// The draft-04 meta-schema uses exclusiveMinimum as a boolean modifier.
+	minimum, hasMinimum := s["minimum"].(float64)
+	exclusive, _ := s["exclusiveMinimum"].(bool)
+
+	if bound, ok := s["exclusiveMinimum"].(float64); ok && value <= bound {
+		fail("must be greater than %v", bound)
+	}
+
+	switch {
+	case hasMinimum && exclusive && value <= minimum:
+		fail("must be greater than %v", minimum)
+
+	case hasMinimum && value < minimum:
+		fail("must be at least %v", minimum)
+	}
+
+	return violations
+}
+
+func (v *metaValidator) validateArray(s map[string]any, value []any, pointer string) []Violation {
+	var violations []Violation
+
+	fail := func(format string, args ...any) {
+		violations = append(violations, Violation{
+			Origin:  Origin{Pointer: pointer},
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if minItems, ok := s["minItems"].(float64); ok && float64(len(value)) < minItems {
+		fail("must have at least %v items", minItems)
+	}
+
+	if unique, _ := s["uniqueItems"].(bool); unique {
+		for i := range value {
+			for j := i + 1; j < len(value); j++ {
+				if reflect.DeepEqual(value[i], value[j]) {
+					fail("items %d and %d must be unique", i, j)
+				}
+			}
+		}
+	}
+
+	if items, ok := s["items"]; ok {
+		for i, item := range value {
+			violations = append(violations, v.validate(items, item, fmt.Sprintf("%s/%d", pointer, i))...)
+		}
+	}
+
+	return violations
+}
+
+func (v *metaValidator) validateObject(s map[string]any, value map[string]any, pointer string) []Violation {
+	var violations []Violation
+
+	properties, _ := s["properties"].(map[string]any)
+
+	for _, name := range sortedKeys(value) {
+		propPointer := pointer + "/" + escapeToken(name)
+
+		if names, ok := s["propertyNames"]; ok {
+			violations = append(violations, v.validate(names, name, propPointer)...)
+		}
+
+		if schema, ok := properties[name]; ok {
+			violations = append(violations, v.validate(schema, value[name], propPointer)...)
+
+			continue
+		}
+
+		if additional, ok := s["additionalProperties"]; ok {
+			violations = append(violations, v.validate(additional, value[name], propPointer)...)
+
+			continue
+		}
+
+		// Only the keywords of a schema are listed as properties.
+		if _, known := metaKeywords[name]; !known && properties != nil {
+			if closest := closestKeyword(name, sortedKeys(properties)); closest != "" {
+				v.misspellings[propPointer] = Violation{
+					Origin:  Origin{Pointer: propPointer},
+					Message: fmt.Sprintf("unknown keyword %q; did you mean %q?", name, closest),
+				}
+			}
+		}
+	}
+
+	dependencies, _ := s["dependencies"].(map[string]any)
+
+	for _, name := range sortedKeys(dependencies) {
+		if _, ok := value[name]; !ok {
+			continue
+		}
+
+		required, ok := dependencies[name].([]any)
+		if !ok {
+			violations = append(violations, v.validate(dependencies[name], value, pointer)...)
+
+			continue
+		}
+
+		for _, r := range required {
+			if _, ok := value[r.(string)]; !ok {
+				violations = append(violations, Violation{
+					Origin:  Origin{Pointer: pointer},
+					Message: fmt.Sprintf("%s requires %s", name, r),
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+func (v *metaValidator) validateApplicators(s map[string]any, instance any, pointer string) []Violation {
+	var violations []Violation
+
+	if allOf, ok := s["allOf"].([]any); ok {
+		for _, sub := range allOf {
+			violations = append(violations, v.validate(sub, instance, pointer)...)
+		}
+	}
+
+	// Of the branches that fail, the one that accepts the type of the
+	// instance explains best what is wrong with it.
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		branches, ok := s[keyword].([]any)
+		if !ok {
+			continue
+		}
+
+		var (
+			closest      []Violation
+			closestTyped bool
+			matches      int
+		)
+
+		for _, branch := range branches {
+			branchViolations := v.validate(branch, instance, pointer)
+			if len(branchViolations) == 0 {
+				matches++
+
+				continue
+			}
+
+			typed := v.acceptsType(branch, instance)
+			if closest == nil || typed && !closestTyped ||
+				typed == closestTyped && len(branchViolations) < len(closest) {
+				closest, closestTyped = branchViolations, typed
+			}
+		}
+
+		switch {
+		case matches == 0:
+			violations = append(violations, closest...)
+
+		case keyword == "oneOf" && matches > 1:
+			violations = append(violations, Violation{
+				Origin:  Origin{Pointer: pointer},
+				Message: "must match exactly one schema of oneOf",
+			})
+		}
+	}
+
+	if not, ok := s["not"]; ok && len(v.validate(not, instance, pointer)) == 0 {
+		violations = append(violations, Violation{Origin: Origin{Pointer: pointer}, Message: "is not allowed"})
+	}
+
+	return violations
+}
+
+// acceptsType reports whether the type of the schema, following its $ref,
+// allows the type of the instance.
+func (v *metaValidator) acceptsType(schema, instance any) bool {
+	s, ok := schema.(map[string]any)
+	if !ok {
+		return schema != false
+	}
+
+	if ref, ok := s["$ref"].(string); ok && !v.acceptsType(v.resolve(ref), instance) {
+		return false
+	}
+
+	types, ok := s["type"]
+
+	return !ok || typeMatches(types, instance)
+}
+
+// resolve returns the subschema of the meta-schema that a $ref points to.
+func (v *metaValidator) resolve(ref string) any {
+	tokens, err := ParsePointer(strings.TrimPrefix(ref, "#"))
+	if err != nil {
+		panic(fmt.Sprintf("invalid $ref %q in meta-schema: %v", ref, err))
+	}
+
+	var current any = v.root
+
+	for _, token := range tokens {
+		object, _ := current.(map[string]any)
+		current = object[token]
+	}
+
+	return current
+}
+
+func typeMatches(types, instance any) bool {
+	name := jsonTypeName(instance)
+
+	list, ok := types.([]any)
+	if !ok {
+		list = []any{types}
+	}
+
+	for _, t := range list {
+		if t == name || t == TypeNameNumber && name == TypeNameInteger {
+			return true
+		}
+	}
+
+	return false
+}
+
+func typeNames(types any) string {
+	list, ok := types.([]any)
+	if !ok {
+		return fmt.Sprint(types)
+	}
+
+	names := make([]string, 0, len(list))
+	for _, t := range list {
+		names = append(names, fmt.Sprint(t))
+	}
+
+	return strings.Join(names, " or ")
+}
+
+func jsonTypeName(instance any) string {
+	switch value := instance.(type) {
+	case nil:
+		return TypeNameNull
+
+	case bool:
+		return TypeNameBoolean
+
+	case float64:
+		if value == math.Trunc(value) {
+			return TypeNameInteger
+		}
+
+		return TypeNameNumber
+
+	case string:
+		return TypeNameString
+
+	case []any:
+		return TypeNameArray
+
+	default:
+		return TypeNameObject
+	}
+}
+
+func formatValues(values []any) string {
+	formatted := make([]string, 0, len(values))
+
+	for _, value := range values {
+		data, _ := json.Marshal(value)
+		formatted = append(formatted, string(data))
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+// suggestion proposes the value of an enum that a string was probably meant
+// to be.
+func suggestion(instance any, enum []any) string {
+	value, ok := instance.(string)
+	if !ok {
+		return ""
+	}
+
+	var candidates []string
+
+	for _, e := range enum {
+		if s, ok := e.(string); ok {
+			candidates = append(candidates, s)
+		}
+	}
+
+	if closest := closestKeyword(value, candidates); closest != "" {
+		return fmt.Sprintf("; did you mean %q?", closest)
+	}
+
+	return ""
+}
+
+// closestKeyword returns the candidate that is a likely misspelling of word,
+// or an empty string if none is close enough.
+func closestKeyword(word string, candidates []string) string {
+	best, bestDistance := "", math.MaxInt
+
+	for _, candidate := range candidates {
+		d := editDistance(strings.ToLower(word), strings.ToLower(candidate))
+		if d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+
+	// Short words are only an edit or two away from too many others.
+	if bestDistance > 2 || bestDistance*4 > len(word) {
+		return ""
+	}
+
+	return best
+}
+
+// editDistance returns the number of insertions, deletions, substitutions
+// and transpositions of adjacent characters that turn a into b.
+func editDistance(a, b string) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return rows[len(a)][len(b)]
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,217 @@
+package schemas
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMeta(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		schema     string
+		dialect    Dialect
+		violations []Violation
+	}{
+		{
+			desc: "valid draft-07",
+			schema: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"type": "object",
+				"properties": {"name": {"type": "string", "minLength": 1}},
+				"required": ["name"],
+				"x-custom": true
+			}`,
+		},
+		{
+			desc: "misspelled keyword",
+			schema: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"type": "object",
+				"requried": ["name"]
+			}`,
+		},
+		{
+			desc: "misspelled type",
+			schema: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"properties": {"count": {"type": "interger"}}
+			}`,
+			dialect: DialectDraft202012,
+			violations: []Violation{{
+				Origin: Origin{Pointer: "/properties/count/type"},
+				Message: `must be one of "array", "boolean", "integer", "null", "number", "object", "string"; ` +
+					`did you mean "integer"?`,
+			}},
+		},
+		{
+			desc: "draft-04 boolean exclusive bound",
+			schema: `{
+				"$schema": "http://json-schema.org/draft-04/schema#",
+				"type": "number",
+				"minimum": 0,
+				"exclusiveMinimum": true
+			}`,
+		},
+		{
+			desc: "draft-04 exclusive bound without limit",
+			schema: `{
+				"$schema": "http://json-schema.org/draft-04/schema#",
+				"type": "number",
+				"exclusiveMaximum": true
+			}`,
+			dialect: DialectDraft04,
+			violations: []Violation{{
+				Origin:  Origin{Pointer: ""},
+				Message: "exclusiveMaximum requires maximum",
+			}},
+		},
+		{
+			desc: "tuple items in draft 2020-12",
+			schema: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "array",
+				"items": [{"type": "string"}]
+			}`,
+			dialect: DialectDraft202012,
+			violations: []Violation{{
+				Origin:  Origin{Pointer: "/items"},
+				Message: "must be of type object or boolean, not array",
+			}},
+		},
+		{
+			desc: "without $schema, any dialect will do",
+			schema: `{
+				"type": "array",
+				"items": [{"type": "string"}],
+				"additionalItems": false
+			}`,
+		},
+		{
+			desc: "without $schema, violations of the newest closest dialect",
+			schema: `{
+				"type": "object",
+				"properties": {"a": {"minLength": -1}}
+			}`,
+			dialect: DialectDraft202012,
+			violations: []Violation{{
+				Origin:  Origin{Pointer: "/properties/a/minLength"},
+				Message: "must be at least 0",
+			}},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			schema, err := FromBytes([]byte(tC.schema))
+			require.NoError(t, err)
+
+			err = schema.ValidateMeta()
+			if tC.violations == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidSchema)
+
+			var metaErr *MetaSchemaError
+			require.ErrorAs(t, err, &metaErr)
+			assert.Equal(t, tC.dialect, metaErr.Dialect)
+			assert.Equal(t, tC.violations, metaErr.Violations)
+		})
+	}
+}
+
+func TestValidateMetaLocatesViolations(t *testing.T) {
+	data := []byte(`$schema: http://json-schema.org/draft-07/schema#
+type: object
+properties:
+  count:
+    type: interger
+`)
+
+	schema, err := FromBytes(data)
+	require.NoError(t, err)
+
+	schema.SetSource("schema.yaml", data)
+
+	var metaErr *MetaSchemaError
+	require.ErrorAs(t, schema.ValidateMeta(), &metaErr)
+	require.Len(t, metaErr.Violations, 1)
+	assert.Equal(t, Origin{File: "schema.yaml", Pointer: "/properties/count/type", Line: 5, Column: 5},
+		metaErr.Violations[0].Origin)
+	assert.Contains(t, metaErr.Error(), `schema.yaml:5:5 (#/properties/count/type): must be one of`)
+}
+
+func TestLintMetaReportsMisspellings(t *testing.T) {
+	data := []byte(`{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "name": {"type": "string", "minLenght": 1},
+    "count": {"type": "interger"}
+  },
+  "requried": ["name"],
+  "x-custom": true
+}`)
+
+	schema, err := FromBytes(data)
+	require.NoError(t, err)
+
+	schema.SetSource("schema.json", data)
+
+	misspellings, err := schema.LintMeta()
+	require.ErrorIs(t, err, ErrInvalidSchema, "misspellings are reported along with violations")
+	assert.Equal(t, []Violation{
+		{
+			Origin:  Origin{File: "schema.json", Pointer: "/properties/name/minLenght", Line: 5, Column: 32},
+			Message: `unknown keyword "minLenght"; did you mean "minLength"?`,
+		},
+		{
+			Origin:  Origin{File: "schema.json", Pointer: "/requried", Line: 8, Column: 3},
+			Message: `unknown keyword "requried"; did you mean "required"?`,
+		},
+	}, misspellings)
+
+	var metaErr *MetaSchemaError
+	require.ErrorAs(t, err, &metaErr)
+	assert.Len(t, metaErr.Violations, 1, "misspellings are not violations")
+}
+
+func TestClosestKeyword(t *testing.T) {
+	keywords := []string{"enum", "required", "properties", "items"}
+
+	assert.Equal(t, "required", closestKeyword("requried", keywords))
+	assert.Equal(t, "properties", closestKeyword("propertes", keywords))
+	assert.Equal(t, "properties", closestKeyword("Properties", keywords))
+	assert.Empty(t, closestKeyword("myEnum", keywords))
+	assert.Equal(t, "items", closestKeyword("item", keywords))
+	assert.Empty(t, closestKeyword("title", keywords))
+}
+
+func TestDecodeErrorsAreMetaSchemaViolations(t *testing.T) {
+	_, err := FromBytes([]byte(`{"type": "integer", "minimum": "3"}`))
+	require.ErrorIs(t, err, ErrInvalidSchema)
+
+	var metaErr *MetaSchemaError
+	require.ErrorAs(t, err, &metaErr)
+	assert.Equal(t, []Violation{{
+		Origin:  Origin{Pointer: "/minimum"},
+		Message: "must be of type number, not string",
+	}}, metaErr.Violations)
+	assert.NotContains(t, err.Error(), "Go struct field")
+
+	fileName := filepath.Join(t.TempDir(), "schema.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte("type: object\nproperties:\n  count:\n    maxLength: long\n"), 0o644))
+
+	_, err = FromYAMLFile(fileName)
+	require.ErrorAs(t, err, &metaErr)
+	require.Len(t, metaErr.Violations, 1)
+	assert.Equal(t, Origin{File: fileName, Pointer: "/properties/count/maxLength", Line: 4, Column: 5},
+		metaErr.Violations[0].Origin)
+	assert.Contains(t, err.Error(), fileName+":4:5 (#/properties/count/maxLength): must be of type integer")
+}
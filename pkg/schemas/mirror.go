@@ -0,0 +1,201 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+var ErrOutsideMirror = errors.New("URI leads outside of its mirror")
+
+// NewMirrorLoader returns a loader that loads the URIs starting with a prefix
+// of mirrors through local, from the location the prefix maps to, so that
+// a schema such as https://schemas.example.com/a.json can be read from
+// ./vendor/schemas/a.json. The longest matching prefix wins. Other URIs are
+// loaded through remote, or fail if it is nil.
+//
+// The rest of a URI is appended to the location of its prefix as it is, so
+// the location of a prefix that ends with "/" is taken as a directory even
+// if it does not end with one itself.
+func NewMirrorLoader(mirrors map[string]string, local, remote Loader) *MirrorLoader {
+	locations := make(map[string]string, len(mirrors))
+	prefixes := make([]string, 0, len(mirrors))
+
+	for prefix, location := range mirrors {
+		if strings.HasSuffix(prefix, "/") && !strings.HasSuffix(location, "/") {
+			location += "/"
+		}
+
+		locations[prefix] = location
+		prefixes = append(prefixes, prefix)
+	}
+
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) > len(prefixes[j])
+	})
+
+	return &MirrorLoader{
+		mirrors:  locations,
+		prefixes: prefixes,
+		local:    local,
+		remote:   remote,
+	}
+}
+
+type MirrorLoader struct {
+	mirrors  map[string]string
+	prefixes []string
+	local    Loader
+	remote   Loader
+}
+
+// Load loads uri, which is resolved against parentURI when it is relative.
+func (l *MirrorLoader) Load(uri, parentURI string) (*Schema, error) {
+	return l.LoadContext(context.Background(), uri, parentURI)
+}
+
+func (l *MirrorLoader) LoadContext(ctx context.Context, uri, parentURI string) (*Schema, error) {
+	uri = resolveAgainstURL(uri, parentURI)
+
+	location, ok, err := l.Rewrite(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		schema, err := LoadContext(ctx, l.local, location, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load mirror %q of %q: %w", location, uri, err)
+		}
+
+		return schema, nil
+	}
+
+	if l.remote == nil {
+		return nil, fmt.Errorf("%w: %q is not mirrored", ErrCannotResolveSchema, uri)
+	}
+
+	return LoadContext(ctx, l.remote, uri, parentURI)
+}
+
+// ResolveDocument resolves the document of a mirrored URI through local, and
+// that of others through remote.
+func (l *MirrorLoader) ResolveDocument(uri, parentURI string) (string, error) {
+	uri = resolveAgainstURL(uri, parentURI)
+
+	location, ok, err := l.Rewrite(uri)
+	if err != nil {
+		return "", err
+	}
+
+	if ok {
+		if local, ok := l.local.(DocumentLoader); ok {
+			return local.ResolveDocument(location, "")
+		}
+
+		return "", errNoDocument
+	}
+
+	if remote, ok := l.remote.(DocumentLoader); ok {
+		return remote.ResolveDocument(uri, parentURI)
+	}
+
+	return "", errNoDocument
+}
+
+func (l *MirrorLoader) ReadDocument(name string) ([]byte, error) {
+	loader := l.remote
+	if ref, err := GetRefType(name); err == nil && ref == RefTypeFile {
+		loader = l.local
+	}
+
+	if loader, ok := loader.(DocumentLoader); ok {
+		return loader.ReadDocument(name)
+	}
+
+	return nil, errNoDocument
+}
+
+func (l *MirrorLoader) localFiles(uri, parentURI string) []string {
+	uri = resolveAgainstURL(uri, parentURI)
+
+	location, ok, err := l.Rewrite(uri)
+	if err != nil {
+		return nil
+	}
+
+	if ok {
+		return localFiles(l.local, location, "")
+	}
+
+	return localFiles(l.remote, uri, parentURI)
+}
+
+// resolveAgainstURL resolves a relative uri against parentURI, if that is
+// an absolute URL.
+func resolveAgainstURL(uri, parentURI string) string {
+	if u, err := url.Parse(uri); err == nil && !u.IsAbs() {
+		if base, err := url.Parse(parentURI); err == nil && base.IsAbs() {
+			return base.ResolveReference(u).String()
+		}
+	}
+
+	return uri
+}
+
+// Rewrite returns the location uri is mirrored at, without its fragment. It
+// fails with ErrOutsideMirror if the rest of uri after its prefix, such as
+// "../../etc/passwd", leads outside of the location the prefix maps to.
+func (l *MirrorLoader) Rewrite(uri string) (string, bool, error) {
+	uri, _, _ = strings.Cut(uri, "#")
+
+	for _, prefix := range l.prefixes {
+		rest, ok := strings.CutPrefix(uri, prefix)
+		if !ok {
+			continue
+		}
+
+		base := l.mirrors[prefix]
+
+		// The rest is either a path below the base, or completes the name
+		// of a file next to it.
+		root := base
+		if !strings.HasSuffix(base, "/") && !strings.HasPrefix(rest, "/") {
+			root = path.Dir(base)
+		}
+
+		location := path.Clean(base + rest)
+		if !withinPath(location, path.Clean(root)) {
+			return "", false, fmt.Errorf("%w: %q", ErrOutsideMirror, uri)
+		}
+
+		return location, true, nil
+	}
+
+	return "", false, nil
+}
+
+// withinPath reports whether a clean slash-separated path is root or below
+// it.
+func withinPath(name, root string) bool {
+	switch {
+	case root == ".":
+		return !path.IsAbs(name) && name != ".." && !strings.HasPrefix(name, "../")
+	case root == "/":
+		return path.IsAbs(name)
+	default:
+		return name == root || strings.HasPrefix(name, root+"/")
+	}
+}
+
+// Mirror makes the HTTP and HTTPS loaders of l load the URIs starting with a
+// prefix of mirrors through local instead, as NewMirrorLoader does.
+func (l MultiLoader) Mirror(mirrors map[string]string, local Loader) {
+	for _, ref := range []RefType{RefTypeHTTP, RefTypeHTTPS} {
+		l[ref] = NewMirrorLoader(mirrors, local, l[ref])
+	}
+}
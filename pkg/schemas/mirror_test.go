@@ -0,0 +1,98 @@
+package schemas
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var catalog = fstest.MapFS{
+	"vendor/a.json":        {Data: []byte(`{"title": "a"}`)},
+	"vendor/nested/b.yaml": {Data: []byte("title: b\n")},
+	"vendor/v2/a.json":     {Data: []byte(`{"title": "a v2"}`)},
+}
+
+func TestFSLoader(t *testing.T) {
+	loader := NewFSLoader(catalog, []string{".json", ".yaml"}, []string{".yaml"})
+
+	testCases := []struct {
+		uri       string
+		parentURI string
+		wantTitle string
+		wantErr   error
+	}{
+		{uri: "vendor/a.json", wantTitle: "a"},
+		{uri: "./vendor/a", wantTitle: "a"},
+		{uri: "/vendor/nested/b", wantTitle: "b"},
+		{uri: "nested/b.yaml", parentURI: "vendor/a.json", wantTitle: "b"},
+		{uri: "../a.json", parentURI: "vendor/nested/b.yaml", wantTitle: "a"},
+		{uri: "../../a.json", parentURI: "vendor/a.json", wantErr: ErrCannotResolveSchema},
+		{uri: "vendor/missing.json", wantErr: ErrCannotResolveSchema},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.uri, func(t *testing.T) {
+			got, err := loader.Load(tC.uri, tC.parentURI)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.wantTitle, got.Title)
+		})
+	}
+}
+
+func TestMirrorLoader(t *testing.T) {
+	loader := NewMirrorLoader(map[string]string{
+		"https://schemas.example.com/":    "./vendor/",
+		"https://schemas.example.com/v2/": "vendor/v2/",
+		"https://nested.example.com/":     "vendor/nested",
+	}, NewFSLoader(catalog, []string{".json", ".yaml"}, []string{".yaml"}), nil)
+
+	testCases := []struct {
+		uri       string
+		parentURI string
+		wantTitle string
+		wantErr   error
+	}{
+		{uri: "https://schemas.example.com/a.json", wantTitle: "a"},
+		{uri: "https://schemas.example.com/a#/$defs/x", wantTitle: "a"},
+		{uri: "https://schemas.example.com/v2/a.json", wantTitle: "a v2"},
+		{uri: "nested/b", parentURI: "https://schemas.example.com/a.json", wantTitle: "b"},
+		{uri: "https://schemas.example.com/missing.json", wantErr: ErrCannotResolveSchema},
+		{uri: "https://other.example.com/a.json", wantErr: ErrCannotResolveSchema},
+		{uri: "https://nested.example.com/b.yaml", wantTitle: "b"},
+		{uri: "https://schemas.example.com/nested/../a.json", wantTitle: "a"},
+		{uri: "https://schemas.example.com/../../etc/passwd", wantErr: ErrOutsideMirror},
+		{uri: "https://schemas.example.com/v2/../a.json", wantErr: ErrOutsideMirror},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.uri, func(t *testing.T) {
+			got, err := loader.Load(tC.uri, tC.parentURI)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.wantTitle, got.Title)
+		})
+	}
+}
+
+func TestMultiLoaderMirror(t *testing.T) {
+	loader := NewDefaultMultiLoader(nil, nil)
+	loader.Mirror(map[string]string{"http://schemas.example.com/": "vendor/"}, NewFSLoader(catalog, nil, nil))
+
+	got, err := loader.Load("http://schemas.example.com/a.json", "")
+	require.NoError(t, err)
+	assert.Equal(t, "a", got.Title)
+	assert.Equal(t, "vendor/a.json", got.source.file)
+}
@@ -24,9 +24,11 @@
 package schemas
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 
 	"dario.cat/mergo"
 )
@@ -42,13 +44,26 @@ type Schema struct {
 	ID          string      `json:"$id"` // RFC draft-wright-json-schema-01, section-9.2.
 	LegacyID    string      `json:"id"`  // RFC draft-wright-json-schema-00, section 4.5.
 	Definitions Definitions `json:"$defs,omitempty"`
+
+	normalized bool
+	warnings   []Warning
+	// document is the JSON the schema was parsed from, and source where it
+	// was read from; see ValidateMeta and SetSource.
+	document []byte
+	source   *source
 }
 
 // UnmarshalJSON implements json.Unmarshaler for Schema struct.
 func (s *Schema) UnmarshalJSON(data []byte) error {
 	var unmarshSchema unmarshalerSchema
-	if err := json.Unmarshal(data, &unmarshSchema); err != nil {
-		return fmt.Errorf("failed to unmarshal schema: %w", err)
+
+	parsed, err := decodeKeywords(data, &unmarshSchema)
+	if err != nil {
+		return decodeError(data, err)
+	}
+
+	if unmarshSchema.ObjectAsType != nil {
+		unmarshSchema.parsed = parsed
 	}
 
 	// Fall back to id if $id is not present.
@@ -58,7 +73,8 @@ func (s *Schema) UnmarshalJSON(data []byte) error {
 
 	// Take care of legacy fields.
 	var legacySchema struct {
-		Definitions Definitions `json:"definitions,omitempty"`
+		Definitions  Definitions                `json:"definitions,omitempty"`
+		Dependencies map[string]json.RawMessage `json:"dependencies,omitempty"`
 	}
 
 	if err := json.Unmarshal(data, &legacySchema); err != nil {
@@ -69,11 +85,65 @@ func (s *Schema) UnmarshalJSON(data []byte) error {
 		unmarshSchema.Definitions = legacySchema.Definitions
 	}
 
+	if unmarshSchema.ObjectAsType != nil {
+		if err := unmarshSchema.addLegacyDependencies(legacySchema.Dependencies); err != nil {
+			return err
+		}
+	}
+
 	*s = Schema(unmarshSchema)
+	s.document = bytes.Clone(data)
+	s.Normalize()
 
 	return nil
 }
 
+// decodeError reports a document that cannot be decoded, such as one with a
+// string minimum, by its meta-schema violations if it has any, since those
+// point at the values at fault rather than at Go fields.
+func decodeError(data []byte, err error) error {
+	var declared struct {
+		Schema string `json:"$schema"`
+	}
+
+	_ = json.Unmarshal(data, &declared)
+
+	if _, metaErr := validateMeta(data, DetectDialect(declared.Schema)); metaErr != nil {
+		return metaErr
+	}
+
+	return fmt.Errorf("failed to unmarshal schema: %w", err)
+}
+
+// FindAnchor returns the definition that declares the given $anchor or
+// $dynamicAnchor. The root schema is reported with an empty name. Only
+// top-level definitions are searched.
+func (s *Schema) FindAnchor(anchor string) (string, *Type, bool) {
+	if anchor == "" {
+		return "", nil, false
+	}
+
+	if s.ObjectAsType != nil && (s.Anchor == anchor || s.DynamicAnchor == anchor) {
+		return "", (*Type)(s.ObjectAsType), true
+	}
+
+	names := make([]string, 0, len(s.Definitions))
+	for name := range s.Definitions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		def := s.Definitions[name]
+		if def.Anchor == anchor || def.DynamicAnchor == anchor {
+			return name, def, true
+		}
+	}
+
+	return "", nil, false
+}
+
 type (
 	unmarshalerSchema Schema
 	ObjectAsType      Type
@@ -146,12 +216,15 @@ type Type struct {
 	// RFC draft-wright-json-schema-00.
 	Version string `json:"$schema,omitempty"` // Section 6.1.
 	Ref     string `json:"$ref,omitempty"`    // Section 7.
+	// RFC draft-bhutton-json-schema-01, section 8.2.1; the root $id is kept
+	// on the Schema.
+	ID string `json:"$id,omitempty"`
 	// RFC draft-wright-json-schema-validation-00, section 5.
 	MultipleOf           *float64         `json:"multipleOf,omitempty"`           // Section 5.1.
 	Maximum              *float64         `json:"maximum,omitempty"`              // Section 5.2.
-	ExclusiveMaximum     *any             `json:"exclusiveMaximum,omitempty"`     // Section 5.3. Changed in draft 4.
+	ExclusiveMaximum     *float64         `json:"exclusiveMaximum,omitempty"`     // Section 5.3. A number from draft 6.
 	Minimum              *float64         `json:"minimum,omitempty"`              // Section 5.4.
-	ExclusiveMinimum     *any             `json:"exclusiveMinimum,omitempty"`     // Section 5.5. Changed in draft 4.
+	ExclusiveMinimum     *float64         `json:"exclusiveMinimum,omitempty"`     // Section 5.5. A number from draft 6.
 	MaxLength            int              `json:"maxLength,omitempty"`            // Section 5.6.
 	MinLength            int              `json:"minLength,omitempty"`            // Section 5.7.
 	Pattern              string           `json:"pattern,omitempty"`              // Section 5.8.
@@ -173,6 +246,9 @@ type Type struct {
 	AnyOf []*Type `json:"anyOf,omitempty"` // Section 10.2.1.2.
 	OneOf []*Type `json:"oneOf,omitempty"` // Section 10.2.1.3.
 	Not   *Type   `json:"not,omitempty"`   // Section 10.2.1.4.
+	If    *Type   `json:"if,omitempty"`    // Section 10.2.2.1.
+	Then  *Type   `json:"then,omitempty"`  // Section 10.2.2.2.
+	Else  *Type   `json:"else,omitempty"`  // Section 10.2.2.3.
 	// RFC draft-wright-json-schema-validation-00, section 6, 7.
 	Title       string      `json:"title,omitempty"`       // Section 6.1.
 	Description string      `json:"description,omitempty"` // Section 6.1.
@@ -186,6 +262,20 @@ type Type struct {
 	// RFC draft-handrews-json-schema-validation-02, appendix A.
 	Definitions      Definitions      `json:"$defs,omitempty"`
 	DependentSchemas map[string]*Type `json:"dependentSchemas,omitempty"`
+	// RFC draft-bhutton-json-schema-01, section 8.
+	Anchor        string `json:"$anchor,omitempty"`        // Section 8.2.2.
+	DynamicRef    string `json:"$dynamicRef,omitempty"`    // Section 8.2.3.2.
+	DynamicAnchor string `json:"$dynamicAnchor,omitempty"` // Section 8.2.2.
+	Comment       string `json:"$comment,omitempty"`       // Section 8.3.
+	// RFC draft-bhutton-json-schema-01, sections 10 and 11.
+	PrefixItems           []*Type `json:"prefixItems,omitempty"`           // Section 10.3.1.1.
+	Contains              *Type   `json:"contains,omitempty"`              // Section 10.3.1.3.
+	PropertyNames         *Type   `json:"propertyNames,omitempty"`         // Section 10.3.2.4.
+	UnevaluatedItems      *Type   `json:"unevaluatedItems,omitempty"`      // Section 11.2.
+	UnevaluatedProperties *Type   `json:"unevaluatedProperties,omitempty"` // Section 11.3.
+	// RFC draft-bhutton-json-schema-validation-01, section 6.4.
+	MaxContains *int `json:"maxContains,omitempty"` // Section 6.4.4.
+	MinContains *int `json:"minContains,omitempty"` // Section 6.4.5.
 
 	// ExtGoCustomType is the name of a (qualified or not) custom Go type
 	// to use for the field.
@@ -196,17 +286,51 @@ type Type struct {
 	subSchemasCount   int           `json:"-"`
 	subSchemaTypeElem bool          `json:"-"`
 
-	Const       *string `json:"const,omitempty"`
-	oneOfParent *Type   `json:"-"`
+	// Const is set when the schema has a const keyword, even if its value is
+	// null, in which case it points to a nil interface.
+	Const       *any  `json:"const,omitempty"` // RFC draft-bhutton-json-schema-validation-01, section 6.1.3.
+	oneOfParent *Type `json:"-"`
 
 	definitionRefName string `json:"-"`
 
 	sharedAttribute *SharedAttr `json:"-"`
 
+	// conditions holds the if/then/else of the types this one was merged from.
+	conditions []*Type `json:"-"`
+
+	// parsed is what Normalize needs to know about the type as it was written.
+	parsed *parsedKeywords `json:"-"`
+	// origin is where the type was written; see Schema.SetSource.
+	origin *Origin `json:"-"`
+	// tupleItems tells that the prefixItems were given as an array of items,
+	// so that JSON pointers into them still resolve.
+	tupleItems bool `json:"-"`
+
 	// Flags.
 	Dereferenced bool `json:"-"` // Marks that his type has been dereferenced.
 }
 
+// IsFalse reports whether the type is the boolean schema `false`, which
+// nothing validates against.
+func (value *Type) IsFalse() bool {
+	if value == nil || value.Not == nil {
+		return false
+	}
+
+	return isEmptyType(*value, true) && isEmptyType(*value.Not, false)
+}
+
+// isEmptyType reports whether the type has no keywords other than, if
+// allowed, not. What is known about where the type was written is ignored.
+func isEmptyType(t Type, allowNot bool) bool {
+	t.parsed, t.origin = nil, nil
+	if allowNot {
+		t.Not = nil
+	}
+
+	return reflect.DeepEqual(t, Type{})
+}
+
 func (value *Type) SetDefinitionRefName(name string) {
 	value.definitionRefName = name
 }
@@ -216,11 +340,13 @@ func (value *Type) GetDefinitionRefName() string {
 }
 
 type SharedAttr struct {
-	Name              string
-	IsConstant        bool
-	IsRequired        bool
-	Type              Type
-	ConstantValuesMap map[string]string
+	Name       string
+	IsConstant bool
+	IsRequired bool
+	Type       Type
+	// ConstantValuesMap holds the const value of the attribute in each child,
+	// keyed by the child's definition ref name or, failing that, its title.
+	ConstantValuesMap map[string]any
 	ParentName        string
 }
 
@@ -245,9 +371,9 @@ func SharedFieldsOfOneOfChildren(types []*Type, parentName string) []SharedAttr
 		isShared := true
 		isConstant := propType.Const != nil
 		isRequired := isPropertyRequired(firstChild, propName)
-		constantValuesMap := map[string]string{}
+		constantValuesMap := map[string]any{}
 		if isConstant {
-			constantValuesMap[firstChild.definitionRefName] = *propType.Const
+			constantValuesMap[firstChild.childKey()] = *propType.Const
 		}
 
 		// Check if this property exists in all other children
@@ -283,9 +409,9 @@ func SharedFieldsOfOneOfChildren(types []*Type, parentName string) []SharedAttr
 			}
 
 			if isConstant {
-				constantValuesMap[otherChild.definitionRefName] = *otherPropType.Const
+				constantValuesMap[otherChild.childKey()] = *otherPropType.Const
 			} else {
-				constantValuesMap[otherChild.definitionRefName] = ""
+				constantValuesMap[otherChild.childKey()] = nil
 			}
 
 		}
@@ -318,6 +444,16 @@ func SharedFieldsOfOneOfChildren(types []*Type, parentName string) []SharedAttr
 	return shared
 }
 
+// childKey identifies a oneOf child: by the ref it was resolved from, or by
+// its title when it was declared inline.
+func (value *Type) childKey() string {
+	if value.definitionRefName != "" {
+		return value.definitionRefName
+	}
+
+	return value.Title
+}
+
 // isPropertyRequired checks if a property is in the required list
 func isPropertyRequired(typ *Type, propName string) bool {
 	if typ.Required == nil {
@@ -359,6 +495,18 @@ func SetAllOneOfParentsForDefinitions(typ *Type) {
 
 }
 
+// Conditions returns the if/then/else subschemas that apply to the type, as
+// types that only have If, Then and Else set.
+func (value *Type) Conditions() []*Type {
+	conditions := value.conditions
+
+	if value.If != nil {
+		conditions = append([]*Type{{If: value.If, Then: value.Then, Else: value.Else}}, conditions...)
+	}
+
+	return conditions
+}
+
 func (value *Type) SetSubSchemaType(sst SubSchemaType) {
 	value.subSchemaType = sst
 }
@@ -398,15 +546,25 @@ func (value *Type) UnmarshalJSON(raw []byte) error {
 	}
 
 	var obj ObjectAsType
-	if err := json.Unmarshal(raw, &obj); err != nil {
+
+	parsed, err := decodeKeywords(raw, &obj)
+	if err != nil {
 		return fmt.Errorf("failed to unmarshal type: %w", err)
 	}
 
+	obj.parsed = parsed
+
 	// Take care of legacy fields from older RFC versions.
 	legacyObj := struct {
 		// RFC draft-wright-json-schema-validation-00, section 5.
-		Dependencies map[string]*Type `json:"dependencies,omitempty"`
-		Definitions  Definitions      `json:"definitions,omitempty"` // Section 5.26.
+		// Section 5.19; each dependency is either a list of property names or a schema.
+		Dependencies map[string]json.RawMessage `json:"dependencies,omitempty"`
+		Definitions  Definitions                `json:"definitions,omitempty"` // Section 5.26.
+		// RFC draft-wright-json-schema-00, section 9.2; a string, unless it
+		// is misused as the name of a property.
+		ID json.RawMessage `json:"id,omitempty"`
+		// Const is decoded again to tell a null const from a missing one.
+		Const json.RawMessage `json:"const,omitempty"`
 	}{}
 	if err := json.Unmarshal(raw, &legacyObj); err != nil {
 		return fmt.Errorf("failed to unmarshal type: %w", err)
@@ -416,8 +574,20 @@ func (value *Type) UnmarshalJSON(raw []byte) error {
 		obj.Definitions = legacyObj.Definitions
 	}
 
-	if legacyObj.Dependencies != nil && obj.DependentSchemas == nil {
-		obj.DependentSchemas = legacyObj.Dependencies
+	if err := obj.addLegacyDependencies(legacyObj.Dependencies); err != nil {
+		return err
+	}
+
+	if obj.ID == "" && len(legacyObj.ID) > 0 && legacyObj.ID[0] == '"' {
+		if err := json.Unmarshal(legacyObj.ID, &obj.ID); err != nil {
+			return fmt.Errorf("failed to unmarshal type: %w", err)
+		}
+	}
+
+	if legacyObj.Const != nil && obj.Const == nil {
+		var null any
+
+		obj.Const = &null
 	}
 
 	*value = Type(obj)
@@ -425,6 +595,44 @@ func (value *Type) UnmarshalJSON(raw []byte) error {
 	return nil
 }
 
+// addLegacyDependencies adds the dependencies keyword of draft 7 and earlier
+// to DependentRequired or DependentSchemas, unless they already cover it.
+func (value *ObjectAsType) addLegacyDependencies(dependencies map[string]json.RawMessage) error {
+	for name, raw := range dependencies {
+		if len(raw) > 0 && raw[0] == '[' {
+			var required []string
+			if err := json.Unmarshal(raw, &required); err != nil {
+				return fmt.Errorf("failed to unmarshal dependencies of %q: %w", name, err)
+			}
+
+			if _, ok := value.DependentRequired[name]; !ok {
+				if value.DependentRequired == nil {
+					value.DependentRequired = map[string][]string{}
+				}
+
+				value.DependentRequired[name] = required
+			}
+
+			continue
+		}
+
+		var schema Type
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			return fmt.Errorf("failed to unmarshal dependencies of %q: %w", name, err)
+		}
+
+		if _, ok := value.DependentSchemas[name]; !ok {
+			if value.DependentSchemas == nil {
+				value.DependentSchemas = map[string]*Type{}
+			}
+
+			value.DependentSchemas[name] = &schema
+		}
+	}
+
+	return nil
+}
+
 func AllOf(types []*Type) (*Type, error) {
 	typ, err := MergeTypes(types)
 	if err != nil {
@@ -481,12 +689,22 @@ func MergeTypes(types []*Type) (*Type, error) {
 		mergo.WithTransformers(typeListTransformer{}),
 	}
 
+	var conditions []*Type
+
 	for _, t := range types {
-		if err := mergo.Merge(result, t, opts...); err != nil {
+		// Conditionals cannot be merged into one, so they are kept aside.
+		conditions = append(conditions, t.Conditions()...)
+
+		c := *t
+		c.If, c.Then, c.Else = nil, nil, nil
+
+		if err := mergo.Merge(result, &c, opts...); err != nil {
 			return nil, fmt.Errorf("%w: %w", ErrCannotMergeTypes, err)
 		}
 	}
 
+	result.conditions = conditions
+
 	return result, nil
 }
 
@@ -1,9 +1,11 @@
 package schemas
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func ptr[T any](v T) *T {
@@ -20,7 +22,7 @@ func TestSharedFieldsOfOneOfChildren(t *testing.T) {
 					Title: "RGB",
 					Properties: map[string]*Type{
 						"model": {
-							Const: ptr("rgb"),
+							Const: ptr[any]("rgb"),
 							Type:  []string{"string"},
 						},
 						"r": {
@@ -38,7 +40,7 @@ func TestSharedFieldsOfOneOfChildren(t *testing.T) {
 					Title: "HSL",
 					Properties: map[string]*Type{
 						"model": {
-							Const: ptr("hsl"),
+							Const: ptr[any]("hsl"),
 							Type:  []string{"string"},
 						},
 						"h": {
@@ -69,7 +71,7 @@ func TestSharedFieldsOfOneOfChildren(t *testing.T) {
 		assert.True(t, shared[0].IsConstant)
 		assert.False(t, shared[0].IsRequired, "Should not be marked as required")
 		assert.Equal(t, TypeList{"string"}, shared[0].Type.Type)
-		assert.Equal(t, map[string]string{
+		assert.Equal(t, map[string]any{
 			"RGB": "rgb",
 			"HSL": "hsl",
 		}, shared[0].ConstantValuesMap)
@@ -236,3 +238,117 @@ func TestSharedFieldsOfOneOfChildren(t *testing.T) {
 		assert.True(t, shared[0].IsRequired, "Should be marked as required")
 	})
 }
+
+func TestUnmarshalDraft2020Keywords(t *testing.T) {
+	var schema Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$comment": "root",
+		"type": "array",
+		"prefixItems": [{"type": "string"}, {"type": "integer"}],
+		"items": false,
+		"contains": {"type": "integer"},
+		"minContains": 1,
+		"maxContains": 3,
+		"unevaluatedItems": false,
+		"$defs": {
+			"named": {
+				"$anchor": "first",
+				"$dynamicAnchor": "second",
+				"propertyNames": {"pattern": "^a"},
+				"unevaluatedProperties": {"type": "string"}
+			},
+			"dynamic": {"$dynamicRef": "#second"}
+		}
+	}`), &schema))
+
+	assert.Equal(t, "root", schema.Comment)
+	assert.Len(t, schema.PrefixItems, 2)
+	assert.True(t, schema.Items.IsFalse())
+	assert.True(t, schema.UnevaluatedItems.IsFalse())
+	assert.False(t, schema.PrefixItems[0].IsFalse())
+	assert.Equal(t, TypeList{"integer"}, schema.Contains.Type)
+	assert.Equal(t, ptr(1), schema.MinContains)
+	assert.Equal(t, ptr(3), schema.MaxContains)
+
+	named := schema.Definitions["named"]
+	assert.Equal(t, "^a", named.PropertyNames.Pattern)
+	assert.Equal(t, TypeList{"string"}, named.UnevaluatedProperties.Type)
+	assert.Equal(t, "#second", schema.Definitions["dynamic"].DynamicRef)
+
+	for _, anchor := range []string{"first", "second"} {
+		name, def, ok := schema.FindAnchor(anchor)
+		assert.True(t, ok)
+		assert.Equal(t, "named", name)
+		assert.Same(t, named, def)
+	}
+
+	_, _, ok := schema.FindAnchor("missing")
+	assert.False(t, ok)
+}
+
+func TestUnmarshalConst(t *testing.T) {
+	var schema Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"definitions": {
+			"number": {"const": 1.5},
+			"boolean": {"const": false},
+			"object": {"const": {"a": [1, "b"]}},
+			"null": {"const": null},
+			"absent": {"type": "string"}
+		}
+	}`), &schema))
+
+	defs := schema.Definitions
+
+	assert.Equal(t, ptr[any](1.5), defs["number"].Const)
+	assert.Equal(t, ptr[any](false), defs["boolean"].Const)
+	assert.Equal(t, ptr[any](map[string]any{"a": []any{1.0, "b"}}), defs["object"].Const)
+	assert.Equal(t, ptr[any](nil), defs["null"].Const)
+	assert.Nil(t, defs["absent"].Const)
+}
+
+func TestMergeTypesKeepsConditions(t *testing.T) {
+	var types []*Type
+
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"type": "object", "if": {"required": ["a"]}, "then": {"required": ["b"]}},
+		{"type": "object", "if": {"required": ["c"]}, "else": {"required": ["d"]}}
+	]`), &types))
+
+	merged, err := AllOf(types)
+	require.NoError(t, err)
+
+	assert.Nil(t, merged.If)
+
+	conditions := merged.Conditions()
+	require.Len(t, conditions, 2)
+	assert.Equal(t, []string{"a"}, conditions[0].If.Required)
+	assert.Equal(t, []string{"b"}, conditions[0].Then.Required)
+	assert.Equal(t, []string{"c"}, conditions[1].If.Required)
+	assert.Equal(t, []string{"d"}, conditions[1].Else.Required)
+}
+
+func TestUnmarshalLegacyDependencies(t *testing.T) {
+	var schema Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "object",
+		"dependencies": {
+			"a": ["b", "c"],
+			"d": {"required": ["e"]}
+		},
+		"definitions": {
+			"nested": {
+				"dependencies": {"f": ["g"]},
+				"dependentRequired": {"f": ["h"]}
+			}
+		}
+	}`), &schema))
+
+	assert.Equal(t, map[string][]string{"a": {"b", "c"}}, schema.DependentRequired)
+	assert.Equal(t, []string{"e"}, schema.DependentSchemas["d"].Required)
+	assert.Equal(t, map[string][]string{"f": {"h"}}, schema.Definitions["nested"].DependentRequired)
+}
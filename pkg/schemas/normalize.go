@@ -0,0 +1,216 @@
+package schemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Warning is a problem with a schema that does not prevent using it.
+type Warning struct {
+	// Pointer locates the subschema the warning is about.
+	Pointer string
+	Message string
+}
+
+// parsedKeywords holds what normalization needs to know about a schema object
+// as it was written: the keywords it used, and the values of those whose form
+// is not that of the canonical model.
+type parsedKeywords struct {
+	names []string
+	// exclusiveMaximum and exclusiveMinimum are set when they are booleans
+	// that modify maximum and minimum, as before draft 6.
+	exclusiveMaximum *bool
+	exclusiveMinimum *bool
+	// tupleItems is set when items is an array, as before draft 2020-12.
+	tupleItems []*Type
+}
+
+// decodeKeywords decodes a schema object into v, except for the keywords
+// whose form is not that of the canonical model, which are returned.
+func decodeKeywords(data []byte, v any) (*parsedKeywords, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	parsed := &parsedKeywords{names: make([]string, 0, len(fields))}
+	for name := range fields {
+		parsed.names = append(parsed.names, name)
+	}
+
+	sort.Strings(parsed.names)
+
+	for _, bound := range []struct {
+		keyword string
+		value   **bool
+	}{
+		{"exclusiveMaximum", &parsed.exclusiveMaximum},
+		{"exclusiveMinimum", &parsed.exclusiveMinimum},
+	} {
+		raw := fields[bound.keyword]
+		if len(raw) == 0 || (raw[0] != 't' && raw[0] != 'f') {
+			continue
+		}
+
+		var exclusive bool
+		if err := json.Unmarshal(raw, &exclusive); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", bound.keyword, err)
+		}
+
+		*bound.value = &exclusive
+
+		delete(fields, bound.keyword)
+	}
+
+	if raw := fields["items"]; len(raw) > 0 && raw[0] == '[' {
+		if err := json.Unmarshal(raw, &parsed.tupleItems); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal items: %w", err)
+		}
+
+		delete(fields, "items")
+	}
+
+	if len(fields) < len(parsed.names) {
+		var err error
+
+		if data, err = json.Marshal(fields); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+// Normalize converts the schema to the canonical model, which is that of
+// draft 2020-12: exclusive bounds are numbers, tuples are described by
+// prefixItems, and the legacy definitions and dependencies are kept in
+// Definitions, DependentRequired and DependentSchemas. It returns warnings
+// about the keywords that the dialect of the schema, or of the resource they
+// are in, does not have; these are used anyway. Normalize runs when a schema
+// is parsed, and later calls return the same warnings.
+func (s *Schema) Normalize() []Warning {
+	if s.normalized {
+		return s.warnings
+	}
+
+	s.normalized = true
+
+	n := &normalizer{visited: map[*Type]struct{}{}}
+	dialect := s.Dialect()
+
+	if s.ObjectAsType != nil {
+		n.walk((*Type)(s.ObjectAsType), "", dialect)
+	}
+
+	for _, name := range sortedTypeNames(s.Definitions) {
+		n.walk(s.Definitions[name], "/$defs/"+escapeToken(name), dialect)
+	}
+
+	s.warnings = n.warnings
+
+	return s.warnings
+}
+
+type normalizer struct {
+	warnings []Warning
+	visited  map[*Type]struct{}
+}
+
+func (n *normalizer) walk(t *Type, pointer string, dialect Dialect) {
+	if t == nil {
+		return
+	}
+
+	if _, ok := n.visited[t]; ok {
+		return
+	}
+
+	n.visited[t] = struct{}{}
+
+	// From draft 2019-09, an embedded resource may declare its own dialect.
+	if d := DetectDialect(t.Version); d != DialectUnknown {
+		dialect = d
+	}
+
+	n.normalize(t, pointer, dialect)
+
+	for _, sub := range t.subschemas() {
+		n.walk(sub.t, pointer+sub.pointer, dialect)
+	}
+}
+
+// normalize converts the keywords of t that are not in the canonical form.
+func (n *normalizer) normalize(t *Type, pointer string, dialect Dialect) {
+	parsed := t.parsed
+	if parsed == nil {
+		return
+	}
+
+	t.parsed = nil
+
+	warn := func(format string, args ...any) {
+		n.warnings = append(n.warnings, Warning{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, name := range parsed.names {
+		if other, ok := keywordDialect(name, dialect); ok {
+			warn("%s is not a keyword of %s; it is interpreted as in %s", name, dialect, other)
+		}
+	}
+
+	for _, bound := range []struct {
+		keyword   string
+		exclusive *bool
+		limit     **float64
+		canonical **float64
+		limitName string
+	}{
+		{"exclusiveMaximum", parsed.exclusiveMaximum, &t.Maximum, &t.ExclusiveMaximum, "maximum"},
+		{"exclusiveMinimum", parsed.exclusiveMinimum, &t.Minimum, &t.ExclusiveMinimum, "minimum"},
+	} {
+		switch {
+		case bound.exclusive == nil:
+			if *bound.canonical != nil && dialect == DialectDraft04 {
+				warn("%s must be a boolean in draft-04; the number is used as an exclusive bound", bound.keyword)
+			}
+
+			continue
+
+		case dialect != DialectUnknown && dialect != DialectDraft04:
+			warn("%s must be a number in %s; the boolean is interpreted as in draft-04", bound.keyword, dialect)
+		}
+
+		if *bound.limit == nil {
+			warn("%s has no effect without %s", bound.keyword, bound.limitName)
+
+			continue
+		}
+
+		if *bound.exclusive {
+			*bound.canonical, *bound.limit = *bound.limit, nil
+		}
+	}
+
+	if parsed.tupleItems != nil {
+		if dialect == DialectDraft202012 {
+			warn("items must be a schema in draft 2020-12; the array is interpreted as prefixItems")
+		}
+
+		if len(t.PrefixItems) > 0 {
+			warn("items is an array and is ignored in favor of prefixItems")
+
+			return
+		}
+
+		// additionalItems describes the items after the tuple, as items does
+		// after prefixItems.
+		t.PrefixItems = parsed.tupleItems
+		t.Items, t.AdditionalItems = t.AdditionalItems, nil
+		t.tupleItems = true
+	}
+}
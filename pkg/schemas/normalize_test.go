@@ -0,0 +1,137 @@
+package schemas
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectDialect(t *testing.T) {
+	testCases := []struct {
+		uri  string
+		want Dialect
+	}{
+		{uri: "http://json-schema.org/draft-04/schema#", want: DialectDraft04},
+		{uri: "http://json-schema.org/draft-06/schema", want: DialectDraft06},
+		{uri: "https://json-schema.org/draft-07/schema#", want: DialectDraft07},
+		{uri: "https://json-schema.org/draft/2019-09/schema", want: DialectDraft201909},
+		{uri: "https://json-schema.org/draft/2020-12/schema", want: DialectDraft202012},
+		{uri: "https://example.com/meta", want: DialectUnknown},
+		{uri: "", want: DialectUnknown},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.uri, func(t *testing.T) {
+			assert.Equal(t, tC.want, DetectDialect(tC.uri))
+		})
+	}
+}
+
+func TestNormalizeExclusiveBounds(t *testing.T) {
+	var schema Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"type": "object",
+		"properties": {
+			"exclusive": {"type": "number", "minimum": 1, "exclusiveMinimum": true, "maximum": 2, "exclusiveMaximum": true},
+			"inclusive": {"type": "number", "minimum": 1, "exclusiveMinimum": false},
+			"number": {"type": "number", "exclusiveMaximum": 3}
+		}
+	}`), &schema))
+
+	assert.Equal(t, DialectDraft04, schema.Dialect())
+
+	exclusive := schema.Properties["exclusive"]
+	assert.Nil(t, exclusive.Minimum)
+	assert.Nil(t, exclusive.Maximum)
+	assert.Equal(t, ptr(1.0), exclusive.ExclusiveMinimum)
+	assert.Equal(t, ptr(2.0), exclusive.ExclusiveMaximum)
+
+	inclusive := schema.Properties["inclusive"]
+	assert.Equal(t, ptr(1.0), inclusive.Minimum)
+	assert.Nil(t, inclusive.ExclusiveMinimum)
+
+	assert.Equal(t, ptr(3.0), schema.Properties["number"].ExclusiveMaximum)
+
+	assert.Equal(t, []Warning{{
+		Pointer: "/properties/number",
+		Message: "exclusiveMaximum must be a boolean in draft-04; the number is used as an exclusive bound",
+	}}, schema.Normalize())
+}
+
+func TestNormalizeTupleItems(t *testing.T) {
+	var schema Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type": "array",
+		"items": [{"type": "string"}, {"type": "integer"}],
+		"additionalItems": {"type": "boolean"},
+		"$defs": {
+			"new": {"type": "string"}
+		}
+	}`), &schema))
+
+	require.Len(t, schema.PrefixItems, 2)
+	assert.Equal(t, TypeList{"integer"}, schema.PrefixItems[1].Type)
+	assert.Equal(t, TypeList{"boolean"}, schema.Items.Type)
+	assert.Nil(t, schema.AdditionalItems)
+
+	// Pointers into the tuple still resolve as written.
+	item, err := schema.ResolvePointer("/items/1")
+	require.NoError(t, err)
+	assert.Equal(t, TypeList{"integer"}, item.Type)
+
+	additional, err := schema.ResolvePointer("/additionalItems")
+	require.NoError(t, err)
+	assert.Equal(t, TypeList{"boolean"}, additional.Type)
+
+	assert.Equal(t, []Warning{{
+		Pointer: "",
+		Message: "$defs is not a keyword of draft-07; it is interpreted as in draft 2019-09",
+	}}, schema.Normalize())
+}
+
+func TestNormalizeKeywordDialects(t *testing.T) {
+	var schema Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"list": {"type": "array", "items": [{"type": "string"}], "additionalItems": false},
+			"legacy": {
+				"$schema": "http://json-schema.org/draft-06/schema#",
+				"type": "number",
+				"maximum": 1,
+				"exclusiveMaximum": true,
+				"if": {"type": "number"}
+			}
+		}
+	}`), &schema))
+
+	assert.Equal(t, []Warning{
+		{
+			Pointer: "/properties/legacy",
+			Message: "if is not a keyword of draft-06; it is interpreted as in draft-07",
+		},
+		{
+			Pointer: "/properties/legacy",
+			Message: "exclusiveMaximum must be a number in draft-06; the boolean is interpreted as in draft-04",
+		},
+		{
+			Pointer: "/properties/list",
+			Message: "additionalItems is not a keyword of draft 2020-12; it is interpreted as in draft 2019-09",
+		},
+		{
+			Pointer: "/properties/list",
+			Message: "items must be a schema in draft 2020-12; the array is interpreted as prefixItems",
+		},
+	}, schema.Normalize())
+
+	assert.Equal(t, ptr(1.0), schema.Properties["legacy"].ExclusiveMaximum)
+	assert.True(t, schema.Properties["list"].Items.IsFalse())
+}
@@ -0,0 +1,259 @@
+package schemas
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Origin is where a subschema was written.
+type Origin struct {
+	// File is the file name or URI of the document.
+	File    string `json:"file"`
+	Pointer string `json:"pointer"`
+	// Line and Column are 1-based, and zero when unknown.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+func (o Origin) String() string {
+	if o.Line == 0 && o.Pointer == "" {
+		return o.File
+	}
+
+	if o.Line == 0 {
+		return fmt.Sprintf("%s#%s", o.File, o.Pointer)
+	}
+
+	return fmt.Sprintf("%s:%d:%d (#%s)", o.File, o.Line, o.Column, o.Pointer)
+}
+
+// Origin returns where the type was written, which is unknown for the types
+// that the generator derives from others.
+func (value *Type) Origin() Origin {
+	if value.origin == nil {
+		return Origin{}
+	}
+
+	return *value.origin
+}
+
+// SetSource records the document that the schema was parsed from on each of
+// its subschemas. The positions are taken from data, which may be JSON or
+// YAML; they are left unknown if it cannot be parsed.
+func (s *Schema) SetSource(file string, data []byte) {
+	src := newSource(file, data)
+
+	s.setSource(src.file, src.positions)
+}
+
+// setSource records the file and the positions of a document on each of the
+// subschemas of the schema.
+func (s *Schema) setSource(file string, positions map[string][2]int) {
+	s.source = &source{file: file, positions: positions}
+
+	visited := map[*Type]struct{}{}
+
+	var walk func(t *Type, pointer string)
+
+	walk = func(t *Type, pointer string) {
+		if t == nil {
+			return
+		}
+
+		if _, ok := visited[t]; ok {
+			return
+		}
+
+		visited[t] = struct{}{}
+
+		position := positions[pointer]
+		t.origin = &Origin{File: file, Pointer: pointer, Line: position[0], Column: position[1]}
+
+		for _, sub := range t.subschemas() {
+			walk(sub.t, pointer+sub.pointer)
+		}
+	}
+
+	if s.ObjectAsType != nil {
+		walk((*Type)(s.ObjectAsType), "")
+	}
+
+	for _, name := range sortedTypeNames(s.Definitions) {
+		walk(s.Definitions[name], "/$defs/"+escapeToken(name))
+	}
+}
+
+// source is a document that a schema was parsed from.
+type source struct {
+	file      string
+	positions map[string][2]int
+}
+
+func newSource(file string, data []byte) *source {
+	positions := map[string][2]int{}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err == nil && len(doc.Content) > 0 {
+		indexPositions(positions, doc.Content[0], "")
+	}
+
+	return &source{file: file, positions: positions}
+}
+
+// origin returns where the value at the pointer was written. The source may
+// be nil, in which case only the pointer is known.
+func (src *source) origin(pointer string) Origin {
+	if src == nil {
+		return Origin{Pointer: pointer}
+	}
+
+	position := src.positions[pointer]
+
+	return Origin{File: src.file, Pointer: pointer, Line: position[0], Column: position[1]}
+}
+
+// legacyKeywords are the keywords whose subschemas the model keeps under
+// another name.
+var legacyKeywords = map[string]string{
+	"definitions":  "$defs",
+	"dependencies": "dependentSchemas",
+}
+
+// indexPositions records the line and column of every value in the node by
+// its JSON pointer. Mapping values are located by their key, which is where
+// a block mapping starts to describe them.
+func indexPositions(positions map[string][2]int, node *yaml.Node, pointer string) {
+	if _, ok := positions[pointer]; !ok {
+		positions[pointer] = [2]int{node.Line, node.Column}
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			position := [2]int{key.Line, key.Column}
+
+			token := pointer + "/" + escapeToken(key.Value)
+			positions[token] = position
+			indexPositions(positions, value, token)
+
+			if canonical, ok := legacyKeywords[key.Value]; ok {
+				token = pointer + "/" + canonical
+				if _, ok := positions[token]; !ok {
+					indexPositions(positions, value, token)
+				}
+			}
+		}
+
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			indexPositions(positions, item, pointer+"/"+strconv.Itoa(i))
+		}
+	}
+}
+
+// subschema is a subschema of a type, with its pointer relative to the type.
+type subschema struct {
+	pointer string
+	t       *Type
+}
+
+// subschemas returns the subschemas of the type, with the pointers they have
+// in the document as it was written.
+func (value *Type) subschemas() []subschema {
+	var subs []subschema
+
+	for _, keyword := range []struct {
+		name  string
+		types map[string]*Type
+	}{
+		{"properties", value.Properties},
+		{"patternProperties", value.PatternProperties},
+		{"dependentSchemas", value.DependentSchemas},
+		{"$defs", value.Definitions},
+	} {
+		for _, name := range sortedTypeNames(keyword.types) {
+			subs = append(subs, subschema{"/" + keyword.name + "/" + escapeToken(name), keyword.types[name]})
+		}
+	}
+
+	// Tuples written as an array of items are kept as prefixItems.
+	prefixItems, items := "prefixItems", "items"
+	if value.tupleItems {
+		prefixItems, items = "items", "additionalItems"
+	}
+
+	for _, keyword := range []struct {
+		name  string
+		types []*Type
+	}{
+		{"allOf", value.AllOf},
+		{"anyOf", value.AnyOf},
+		{"oneOf", value.OneOf},
+		{prefixItems, value.PrefixItems},
+	} {
+		for i, child := range keyword.types {
+			subs = append(subs, subschema{"/" + keyword.name + "/" + strconv.Itoa(i), child})
+		}
+	}
+
+	for _, keyword := range []struct {
+		name string
+		t    *Type
+	}{
+		{items, value.Items},
+		{"additionalItems", value.AdditionalItems},
+		{"additionalProperties", value.AdditionalProperties},
+		{"contains", value.Contains},
+		{"propertyNames", value.PropertyNames},
+		{"not", value.Not},
+		{"if", value.If},
+		{"then", value.Then},
+		{"else", value.Else},
+		{"unevaluatedItems", value.UnevaluatedItems},
+		{"unevaluatedProperties", value.UnevaluatedProperties},
+	} {
+		if keyword.t != nil {
+			subs = append(subs, subschema{"/" + keyword.name, keyword.t})
+		}
+	}
+
+	return subs
+}
+
+// Walk calls fn for the root type and the definitions of the document and
+// for all their subschemas, with the JSON pointers they have in the document
+// as it was written. Subschemas shared by several keywords are visited once.
+func (s *Schema) Walk(fn func(pointer string, t *Type)) {
+	visited := map[*Type]struct{}{}
+
+	var walk func(pointer string, t *Type)
+
+	walk = func(pointer string, t *Type) {
+		if t == nil {
+			return
+		}
+
+		if _, ok := visited[t]; ok {
+			return
+		}
+
+		visited[t] = struct{}{}
+
+		fn(pointer, t)
+
+		for _, sub := range t.subschemas() {
+			walk(pointer+sub.pointer, sub.t)
+		}
+	}
+
+	if s.ObjectAsType != nil {
+		walk("", (*Type)(s.ObjectAsType))
+	}
+
+	for _, name := range sortedTypeNames(s.Definitions) {
+		walk("/$defs/"+escapeToken(name), s.Definitions[name])
+	}
+}
@@ -0,0 +1,74 @@
+package schemas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetSource(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data string
+		want map[string]Origin
+	}{
+		{
+			desc: "json",
+			data: `{
+  "type": "object",
+  "properties": {
+    "name": {"$ref": "#/definitions/name"},
+    "tuple": {"items": [{"type": "string"}, {"type": "integer"}]}
+  },
+  "definitions": {
+    "name": {"type": "string"}
+  }
+}`,
+			want: map[string]Origin{
+				"":                          {File: "schema.json", Pointer: "", Line: 1, Column: 1},
+				"/properties/name":          {File: "schema.json", Pointer: "/properties/name", Line: 4, Column: 5},
+				"/properties/tuple/items/1": {File: "schema.json", Pointer: "/properties/tuple/items/1", Line: 5, Column: 45},
+				"/$defs/name":               {File: "schema.json", Pointer: "/$defs/name", Line: 8, Column: 5},
+			},
+		},
+		{
+			desc: "yaml",
+			data: `type: object
+properties:
+  name:
+    $ref: "#/$defs/name"
+$defs:
+  name:
+    type: string
+`,
+			want: map[string]Origin{
+				"/properties/name": {File: "schema.yaml", Pointer: "/properties/name", Line: 3, Column: 3},
+				"/$defs/name":      {File: "schema.yaml", Pointer: "/$defs/name", Line: 6, Column: 3},
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			schema, err := FromBytes([]byte(tC.data))
+			require.NoError(t, err)
+
+			file := "schema." + tC.desc
+			schema.SetSource(file, []byte(tC.data))
+
+			for pointer, want := range tC.want {
+				typ, err := schema.ResolvePointer(pointer)
+				require.NoError(t, err)
+				assert.Equal(t, want, typ.Origin(), pointer)
+			}
+		})
+	}
+}
+
+func TestOriginString(t *testing.T) {
+	assert.Equal(t, "schema.json", Origin{File: "schema.json"}.String())
+	assert.Equal(t, "schema.json#/properties/a", Origin{File: "schema.json", Pointer: "/properties/a"}.String())
+	assert.Equal(t, "schema.json:3:5 (#/properties/a)",
+		Origin{File: "schema.json", Pointer: "/properties/a", Line: 3, Column: 5}.String())
+}
@@ -1,6 +1,7 @@
 package schemas
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,16 +13,19 @@ import (
 )
 
 func FromJSONFile(fileName string) (*Schema, error) {
-	f, err := os.Open(fileName)
+	data, err := os.ReadFile(fileName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 
-	defer func() {
-		_ = f.Close()
-	}()
+	schema, err := FromJSONReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, locateViolations(err, newSource(fileName, data))
+	}
+
+	schema.SetSource(fileName, data)
 
-	return FromJSONReader(f)
+	return schema, nil
 }
 
 func FromJSONReader(r io.Reader) (*Schema, error) {
@@ -34,16 +38,19 @@ func FromJSONReader(r io.Reader) (*Schema, error) {
 }
 
 func FromYAMLFile(fileName string) (*Schema, error) {
-	f, err := os.Open(fileName)
+	data, err := os.ReadFile(fileName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 
-	defer func() {
-		_ = f.Close()
-	}()
+	schema, err := FromYAMLReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, locateViolations(err, newSource(fileName, data))
+	}
 
-	return FromYAMLReader(f)
+	schema.SetSource(fileName, data)
+
+	return schema, nil
 }
 
 func FromYAMLReader(r io.Reader) (*Schema, error) {
@@ -69,3 +76,33 @@ func FromYAMLReader(r io.Reader) (*Schema, error) {
 
 	return &schema, nil
 }
+
+// FromReader parses a schema that may be either JSON or YAML; see FromBytes.
+func FromReader(r io.Reader) (*Schema, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	return FromBytes(data)
+}
+
+// FromBytes parses a schema that may be either JSON or YAML. The format is
+// detected from the content: a document that starts with '{' is JSON, and
+// anything else is YAML.
+func FromBytes(data []byte) (*Schema, error) {
+	if IsJSON(data) {
+		return FromJSONReader(bytes.NewReader(data))
+	}
+
+	return FromYAMLReader(bytes.NewReader(data))
+}
+
+// IsJSON reports whether data looks like a JSON object, ignoring leading
+// whitespace and a UTF-8 byte order mark.
+func IsJSON(data []byte) bool {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	data = bytes.TrimLeft(data, " \t\r\n")
+
+	return len(data) > 0 && data[0] == '{'
+}
@@ -0,0 +1,198 @@
+package schemas
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPointer   = errors.New("invalid JSON pointer")
+	ErrPointerNotFound  = errors.New("JSON pointer does not resolve")
+	ErrPointerNotSchema = errors.New("JSON pointer does not point to a schema")
+)
+
+// ParsePointer splits a JSON pointer into its unescaped reference tokens, as
+// described in RFC 6901. The empty pointer refers to the whole document.
+func ParsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: %q must start with /", ErrInvalidPointer, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+
+	for i, token := range tokens {
+		// A ~ must be followed by 0 or 1.
+		for j := range len(token) {
+			if token[j] == '~' && (j+1 == len(token) || (token[j+1] != '0' && token[j+1] != '1')) {
+				return nil, fmt.Errorf("%w: %q has an invalid escape in %q", ErrInvalidPointer, pointer, token)
+			}
+		}
+
+		// ~1 must be replaced first, so that ~01 becomes ~1 rather than /.
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+// ResolvePointer returns the subschema a JSON pointer refers to, following the
+// keywords that hold subschemas.
+func (s *Schema) ResolvePointer(pointer string) (*Type, error) {
+	tokens, err := ParsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	// The root definitions are kept on the schema rather than its type.
+	if len(tokens) >= 2 && (tokens[0] == "$defs" || tokens[0] == "definitions") {
+		def, ok := s.Definitions[tokens[1]]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q: no definition %q", ErrPointerNotFound, pointer, tokens[1])
+		}
+
+		return resolveTokens(def, pointer, tokens[2:])
+	}
+
+	if s.ObjectAsType == nil {
+		return nil, fmt.Errorf("%w: %q: schema has no root type", ErrPointerNotFound, pointer)
+	}
+
+	return resolveTokens((*Type)(s.ObjectAsType), pointer, tokens)
+}
+
+func resolveTokens(t *Type, pointer string, tokens []string) (*Type, error) {
+	for len(tokens) > 0 {
+		keyword := tokens[0]
+
+		var (
+			next     *Type
+			consumed = 1
+		)
+
+		named := func(types map[string]*Type) error {
+			if len(tokens) < 2 {
+				return fmt.Errorf("%w: %q: %s is not a schema", ErrPointerNotSchema, pointer, keyword)
+			}
+
+			consumed = 2
+
+			child, ok := types[tokens[1]]
+			if !ok {
+				return fmt.Errorf("%w: %q: no %s %q", ErrPointerNotFound, pointer, keyword, tokens[1])
+			}
+
+			next = child
+
+			return nil
+		}
+
+		indexed := func(types []*Type) error {
+			if len(tokens) < 2 {
+				return fmt.Errorf("%w: %q: %s is not a schema", ErrPointerNotSchema, pointer, keyword)
+			}
+
+			consumed = 2
+
+			// Leading zeros are not allowed in array indices.
+			i, err := strconv.Atoi(tokens[1])
+			if err != nil || i < 0 || i >= len(types) || strconv.Itoa(i) != tokens[1] {
+				return fmt.Errorf("%w: %q: no %s at index %q", ErrPointerNotFound, pointer, keyword, tokens[1])
+			}
+
+			next = types[i]
+
+			return nil
+		}
+
+		var err error
+
+		switch keyword {
+		case "properties":
+			err = named(t.Properties)
+
+		case "patternProperties":
+			err = named(t.PatternProperties)
+
+		case "dependentSchemas":
+			err = named(t.DependentSchemas)
+
+		case "$defs", "definitions":
+			err = named(t.Definitions)
+
+		case "allOf":
+			err = indexed(t.AllOf)
+
+		case "anyOf":
+			err = indexed(t.AnyOf)
+
+		case "oneOf":
+			err = indexed(t.OneOf)
+
+		case "prefixItems":
+			err = indexed(t.PrefixItems)
+
+		// Tuples written as an array of items are kept as prefixItems.
+		case "items":
+			if t.tupleItems {
+				err = indexed(t.PrefixItems)
+			} else {
+				next = t.Items
+			}
+
+		case "additionalItems":
+			if t.tupleItems {
+				next = t.Items
+			} else {
+				next = t.AdditionalItems
+			}
+
+		case "additionalProperties":
+			next = t.AdditionalProperties
+
+		case "contains":
+			next = t.Contains
+
+		case "propertyNames":
+			next = t.PropertyNames
+
+		case "not":
+			next = t.Not
+
+		case "if":
+			next = t.If
+
+		case "then":
+			next = t.Then
+
+		case "else":
+			next = t.Else
+
+		case "unevaluatedItems":
+			next = t.UnevaluatedItems
+
+		case "unevaluatedProperties":
+			next = t.UnevaluatedProperties
+
+		default:
+			return nil, fmt.Errorf("%w: %q: %s is not a keyword with subschemas", ErrPointerNotSchema, pointer, keyword)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if next == nil {
+			return nil, fmt.Errorf("%w: %q: no %s", ErrPointerNotFound, pointer, keyword)
+		}
+
+		t, tokens = next, tokens[consumed:]
+	}
+
+	return t, nil
+}
@@ -0,0 +1,91 @@
+package schemas
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePointer(t *testing.T) {
+	testCases := []struct {
+		pointer string
+		want    []string
+		wantErr error
+	}{
+		{pointer: "", want: nil},
+		{pointer: "/", want: []string{""}},
+		{pointer: "/$defs/a", want: []string{"$defs", "a"}},
+		{pointer: "/$defs/a~1b", want: []string{"$defs", "a/b"}},
+		{pointer: "/$defs/c~0d", want: []string{"$defs", "c~d"}},
+		{pointer: "/$defs/~01", want: []string{"$defs", "~1"}},
+		{pointer: "$defs/a", wantErr: ErrInvalidPointer},
+		{pointer: "/$defs/a~2", wantErr: ErrInvalidPointer},
+		{pointer: "/$defs/a~", wantErr: ErrInvalidPointer},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.pointer, func(t *testing.T) {
+			got, err := ParsePointer(tC.pointer)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.want, got)
+		})
+	}
+}
+
+func TestResolvePointer(t *testing.T) {
+	var schema Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"spec": {"type": "array", "items": {"title": "item"}},
+			"a/b": {"title": "slashed"}
+		},
+		"allOf": [{"title": "first"}, {"title": "second"}],
+		"definitions": {
+			"outer": {
+				"$defs": {"inner": {"title": "inner"}},
+				"not": {"title": "negated"}
+			}
+		}
+	}`), &schema))
+
+	testCases := []struct {
+		pointer   string
+		wantTitle string
+		wantErr   error
+	}{
+		{pointer: "/properties/spec/items", wantTitle: "item"},
+		{pointer: "/properties/a~1b", wantTitle: "slashed"},
+		{pointer: "/allOf/1", wantTitle: "second"},
+		{pointer: "/$defs/outer/$defs/inner", wantTitle: "inner"},
+		{pointer: "/definitions/outer/not", wantTitle: "negated"},
+		{pointer: "/allOf/01", wantErr: ErrPointerNotFound},
+		{pointer: "/allOf/2", wantErr: ErrPointerNotFound},
+		{pointer: "/properties/missing", wantErr: ErrPointerNotFound},
+		{pointer: "/properties", wantErr: ErrPointerNotSchema},
+		{pointer: "/type", wantErr: ErrPointerNotSchema},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.pointer, func(t *testing.T) {
+			got, err := schema.ResolvePointer(tC.pointer)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.wantTitle, got.Title)
+		})
+	}
+}
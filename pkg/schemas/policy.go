@@ -0,0 +1,330 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrOutsideRoots     = errors.New("file is outside the allowed directories")
+	ErrSchemeNotAllowed = errors.New("URL scheme is not allowed")
+	ErrHostNotAllowed   = errors.New("URL host is not allowed")
+	ErrDocumentTooLarge = errors.New("document is too large")
+	ErrRefTooDeep       = errors.New("$ref is nested too deeply")
+)
+
+// Policy restricts the schemas that a PolicyLoader loads. The zero value
+// allows everything.
+type Policy struct {
+	// Roots are the directories that local files must be in, after
+	// resolving symlinks; none allows any directory.
+	Roots []string
+	// Schemes are the schemes allowed for URIs other than local files, such
+	// as https; none allows any scheme.
+	Schemes []string
+	// Hosts are the hosts allowed for URLs, with their port if any, as in
+	// URL.Host; none allows any host.
+	Hosts []string
+	// MaxBytes limits the size of a document; zero means no limit. Local
+	// files are checked before they are read and other documents once they
+	// are loaded, so remote loaders should limit their downloads as well, as
+	// HTTPLoader.MaxBytes does.
+	MaxBytes int64
+	// MaxDepth limits how many documents deep a $ref may be from a document
+	// loaded without a parent, which is at depth 0; zero means no limit.
+	MaxDepth int
+}
+
+// PolicyError is the error of a $ref that a PolicyLoader refused. It wraps
+// one of ErrOutsideRoots, ErrSchemeNotAllowed, ErrHostNotAllowed,
+// ErrDocumentTooLarge and ErrRefTooDeep.
+type PolicyError struct {
+	// Ref is the URI that was refused, and ParentURI the document that
+	// refers to it, if any.
+	Ref       string
+	ParentURI string
+	Err       error
+}
+
+func (e *PolicyError) Error() string {
+	if e.ParentURI == "" {
+		return fmt.Sprintf("refused to load %q: %v", e.Ref, e.Err)
+	}
+
+	return fmt.Sprintf("refused to load $ref %q from %s: %v", e.Ref, e.ParentURI, e.Err)
+}
+
+func (e *PolicyError) Unwrap() error {
+	return e.Err
+}
+
+// NewPolicyLoader returns a loader that loads schemas through loader, such as
+// a MultiLoader, unless policy refuses them.
+func NewPolicyLoader(loader Loader, policy Policy, resolveExtensions []string) *PolicyLoader {
+	return &PolicyLoader{
+		loader:            loader,
+		policy:            policy,
+		resolveExtensions: resolveExtensions,
+		depths:            &refDepths{depths: map[string]int{}},
+	}
+}
+
+type PolicyLoader struct {
+	loader            Loader
+	policy            Policy
+	resolveExtensions []string
+	depths            *refDepths
+}
+
+// refDepths holds the depth of every document loaded so far, by the names
+// it is referred to as a parent. The policy loaders of a MultiLoader share
+// them, since a document may refer to others of any type.
+type refDepths struct {
+	mu     sync.Mutex
+	depths map[string]int
+}
+
+// Restrict makes l load every schema through a PolicyLoader, so that policy
+// applies to the refs of all types. Call it before Mirror, so that mirrored
+// files are checked against the roots of the policy as well.
+func (l MultiLoader) Restrict(policy Policy, resolveExtensions []string) {
+	depths := &refDepths{depths: map[string]int{}}
+
+	for ref, loader := range l {
+		l[ref] = &PolicyLoader{
+			loader:            loader,
+			policy:            policy,
+			resolveExtensions: resolveExtensions,
+			depths:            depths,
+		}
+	}
+}
+
+func (l *PolicyLoader) Load(uri, parentURI string) (*Schema, error) {
+	return l.LoadContext(context.Background(), uri, parentURI)
+}
+
+func (l *PolicyLoader) LoadContext(ctx context.Context, uri, parentURI string) (*Schema, error) {
+	refuse := func(err error) error {
+		return &PolicyError{Ref: uri, ParentURI: parentURI, Err: err}
+	}
+
+	depth, err := l.check(uri, parentURI)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every redirect of an HTTP request is held to the policy as well.
+	ctx = withRedirectCheck(ctx, func(u *url.URL) error {
+		if err := l.checkURL(u.String()); err != nil {
+			return refuse(fmt.Errorf("redirect to %s: %w", u, err))
+		}
+
+		return nil
+	})
+
+	schema, err := LoadContext(ctx, l.loader, uri, parentURI)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.policy.MaxBytes > 0 && int64(len(schema.document)) > l.policy.MaxBytes {
+		return nil, refuse(fmt.Errorf("%w: more than %d bytes", ErrDocumentTooLarge, l.policy.MaxBytes))
+	}
+
+	l.setDepth(uri, parentURI, depth)
+
+	return schema, nil
+}
+
+// ResolveDocument resolves the document of uri through the loader, unless
+// the policy refuses it. Since the document may then be served without
+// loading it, its depth is recorded as a load would.
+func (l *PolicyLoader) ResolveDocument(uri, parentURI string) (string, error) {
+	loader, ok := l.loader.(DocumentLoader)
+	if !ok {
+		return "", errNoDocument
+	}
+
+	depth, err := l.check(uri, parentURI)
+	if err != nil {
+		return "", err
+	}
+
+	name, err := loader.ResolveDocument(uri, parentURI)
+	if err != nil {
+		return "", err
+	}
+
+	l.setDepth(uri, parentURI, depth)
+
+	return name, nil
+}
+
+func (l *PolicyLoader) ReadDocument(name string) ([]byte, error) {
+	loader, ok := l.loader.(DocumentLoader)
+	if !ok {
+		return nil, errNoDocument
+	}
+
+	data, err := loader.ReadDocument(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.policy.MaxBytes > 0 && int64(len(data)) > l.policy.MaxBytes {
+		return nil, &PolicyError{
+			Ref: name,
+			Err: fmt.Errorf("%w: more than %d bytes", ErrDocumentTooLarge, l.policy.MaxBytes),
+		}
+	}
+
+	return data, nil
+}
+
+func (l *PolicyLoader) localFiles(uri, parentURI string) []string {
+	return localFiles(l.loader, uri, parentURI)
+}
+
+// check refuses the URIs that the policy does not allow, and returns the
+// depth of the others.
+func (l *PolicyLoader) check(uri, parentURI string) (int, error) {
+	refuse := func(err error) error {
+		return &PolicyError{Ref: uri, ParentURI: parentURI, Err: err}
+	}
+
+	depth := 0
+	if parentURI != "" {
+		depth = l.depth(parentURI) + 1
+	}
+
+	if l.policy.MaxDepth > 0 && depth > l.policy.MaxDepth {
+		return 0, refuse(fmt.Errorf("%w: more than %d", ErrRefTooDeep, l.policy.MaxDepth))
+	}
+
+	ref, err := GetRefType(uri)
+	if err != nil {
+		return 0, err
+	}
+
+	if ref == RefTypeFile {
+		if err := l.checkFile(uri, parentURI); err != nil {
+			return 0, refuse(err)
+		}
+	} else if err := l.checkURL(uri); err != nil {
+		return 0, refuse(err)
+	}
+
+	return depth, nil
+}
+
+// checkFile refuses the files outside of the roots, and those that are too
+// large.
+func (l *PolicyLoader) checkFile(uri, parentURI string) error {
+	fileName := strings.TrimPrefix(uri, "file://")
+	if !filepath.IsAbs(fileName) {
+		fileName = filepath.Join(filepath.Dir(parentURI), fileName)
+	}
+
+	// A file that does not exist is checked by its name, so that the error
+	// does not tell whether there is one outside of the roots.
+	if qualified, err := QualifiedFileName(uri, parentURI, l.resolveExtensions); err == nil {
+		fileName = qualified
+	}
+
+	if len(l.policy.Roots) > 0 && !slices.ContainsFunc(l.policy.Roots, func(root string) bool {
+		return inDirectory(fileName, root)
+	}) {
+		return ErrOutsideRoots
+	}
+
+	if info, err := os.Stat(fileName); err == nil && l.policy.MaxBytes > 0 && info.Size() > l.policy.MaxBytes {
+		return fmt.Errorf("%w: more than %d bytes", ErrDocumentTooLarge, l.policy.MaxBytes)
+	}
+
+	return nil
+}
+
+func (l *PolicyLoader) checkURL(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotParseRef, err)
+	}
+
+	if len(l.policy.Schemes) > 0 && !slices.Contains(l.policy.Schemes, u.Scheme) {
+		return fmt.Errorf("%w: %s", ErrSchemeNotAllowed, u.Scheme)
+	}
+
+	if u.Host != "" && len(l.policy.Hosts) > 0 && !slices.Contains(l.policy.Hosts, u.Host) {
+		return fmt.Errorf("%w: %s", ErrHostNotAllowed, u.Host)
+	}
+
+	return nil
+}
+
+func (l *PolicyLoader) depth(parentURI string) int {
+	l.depths.mu.Lock()
+	defer l.depths.mu.Unlock()
+
+	if depth, ok := l.depths.depths[parentURI]; ok {
+		return depth
+	}
+
+	return l.depths.depths[filepath.Clean(parentURI)]
+}
+
+// setDepth records the depth of a document under the names that the
+// generator uses as the parent of its $refs: the name it was loaded by, if
+// it has no parent, and its qualified name.
+func (l *PolicyLoader) setDepth(uri, parentURI string, depth int) {
+	names := []string{}
+	if parentURI == "" {
+		names = append(names, uri, filepath.Clean(uri))
+	}
+
+	if qualified, err := QualifiedFileName(uri, parentURI, l.resolveExtensions); err == nil {
+		names = append(names, qualified)
+	}
+
+	l.depths.mu.Lock()
+	defer l.depths.mu.Unlock()
+
+	for _, name := range names {
+		if known, ok := l.depths.depths[name]; !ok || depth < known {
+			l.depths.depths[name] = depth
+		}
+	}
+}
+
+// inDirectory reports whether a file is within dir, after resolving the
+// symlinks of both that exist.
+func inDirectory(fileName, dir string) bool {
+	fileName, err := filepath.Abs(fileName)
+	if err != nil {
+		return false
+	}
+
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+
+	if resolved, err := filepath.EvalSymlinks(fileName); err == nil {
+		fileName = resolved
+	}
+
+	rel, err := filepath.Rel(dir, fileName)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
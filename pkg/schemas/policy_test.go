@@ -0,0 +1,216 @@
+package schemas
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyLoaderFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	writeSchema := func(name, content string) string {
+		t.Helper()
+
+		fileName := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0o755))
+		require.NoError(t, os.WriteFile(fileName, []byte(content), 0o644))
+
+		return fileName
+	}
+
+	root := writeSchema("root/a.json", `{"title": "a"}`)
+	writeSchema("root/large.json", `{"title": "`+strings.Repeat("x", 100)+`"}`)
+	writeSchema("outside/secret.json", `{"title": "secret"}`)
+	require.NoError(t, os.Symlink(filepath.Join(dir, "outside"), filepath.Join(dir, "root", "link")))
+
+	extensions := []string{".json"}
+	loader := NewPolicyLoader(NewFileLoader(extensions, nil), Policy{
+		Roots:    []string{filepath.Join(dir, "root")},
+		MaxBytes: 100,
+	}, extensions)
+
+	testCases := []struct {
+		uri       string
+		wantTitle string
+		wantErr   error
+	}{
+		{uri: "a", wantTitle: "a"},
+		{uri: "./sub/../a.json", wantTitle: "a"},
+		{uri: "../outside/secret.json", wantErr: ErrOutsideRoots},
+		{uri: "../outside/missing.json", wantErr: ErrOutsideRoots},
+		{uri: "link/secret.json", wantErr: ErrOutsideRoots},
+		{uri: "file://" + filepath.Join(dir, "outside", "secret.json"), wantErr: ErrOutsideRoots},
+		{uri: "large.json", wantErr: ErrDocumentTooLarge},
+		{uri: "missing.json", wantErr: ErrCannotResolveSchema},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.uri, func(t *testing.T) {
+			got, err := loader.Load(tC.uri, root)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.wantTitle, got.Title)
+		})
+	}
+
+	_, err := loader.Load("../outside/secret.json", root)
+
+	var policyErr *PolicyError
+	require.ErrorAs(t, err, &policyErr)
+	assert.Equal(t, "../outside/secret.json", policyErr.Ref)
+	assert.Equal(t, root, policyErr.ParentURI)
+	assert.EqualError(t, err, `refused to load $ref "../outside/secret.json" from `+root+
+		`: file is outside the allowed directories`)
+}
+
+func TestPolicyLoaderURLs(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.RegisterBytes([]byte(`{"$id": "https://schemas.example.com/a", "title": "a"}`)))
+	require.NoError(t, registry.RegisterBytes([]byte(`{"$id": "http://schemas.example.com/a", "title": "a"}`)))
+	require.NoError(t, registry.RegisterBytes([]byte(`{"$id": "https://other.example.com/a", "title": "a"}`)))
+	require.NoError(t, registry.RegisterBytes([]byte(`{"$id": "https://schemas.example.com/large", "title": "`+
+		strings.Repeat("x", 100)+`"}`)))
+
+	loader := NewPolicyLoader(registry, Policy{
+		Schemes:  []string{"https"},
+		Hosts:    []string{"schemas.example.com"},
+		MaxBytes: 100,
+	}, nil)
+
+	testCases := []struct {
+		uri     string
+		wantErr error
+	}{
+		{uri: "https://schemas.example.com/a"},
+		{uri: "http://schemas.example.com/a", wantErr: ErrSchemeNotAllowed},
+		{uri: "https://other.example.com/a", wantErr: ErrHostNotAllowed},
+		{uri: "urn:example:a", wantErr: ErrSchemeNotAllowed},
+		{uri: "https://schemas.example.com/large", wantErr: ErrDocumentTooLarge},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.uri, func(t *testing.T) {
+			_, err := loader.Load(tC.uri, "")
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPolicyLoaderRedirects(t *testing.T) {
+	var disallowedHits atomic.Int32
+
+	disallowed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		disallowedHits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"title": "elsewhere"}`))
+	}))
+	defer disallowed.Close()
+
+	allowed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/away.json":
+			http.Redirect(w, r, disallowed.URL+"/a.json", http.StatusFound)
+
+		case "/moved.json":
+			http.Redirect(w, r, "/a.json", http.StatusMovedPermanently)
+
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"title": "a"}`))
+		}
+	}))
+	defer allowed.Close()
+
+	host := strings.TrimPrefix(allowed.URL, "http://")
+	loader := NewPolicyLoader(NewHTTPLoader(nil), Policy{Schemes: []string{"http"}, Hosts: []string{host}}, nil)
+
+	got, err := loader.Load(allowed.URL+"/moved.json", "")
+	require.NoError(t, err)
+	assert.Equal(t, "a", got.Title)
+
+	_, err = loader.Load(allowed.URL+"/away.json", "")
+	require.ErrorIs(t, err, ErrHostNotAllowed)
+
+	var policyErr *PolicyError
+	require.ErrorAs(t, err, &policyErr)
+	assert.Equal(t, allowed.URL+"/away.json", policyErr.Ref)
+	assert.Zero(t, disallowedHits.Load(), "the redirect is not followed")
+
+	httpLoader := NewHTTPLoader(nil)
+	httpLoader.CheckRedirect = func(u *url.URL) error {
+		return errors.New("redirect to " + u.Host)
+	}
+
+	_, err = httpLoader.Load(allowed.URL+"/away.json", "")
+	require.ErrorContains(t, err, "redirect to "+strings.TrimPrefix(disallowed.URL, "http://"))
+	assert.Zero(t, disallowedHits.Load())
+}
+
+func TestPolicyLoaderDepth(t *testing.T) {
+	registry := NewRegistry()
+	for _, name := range []string{"a", "b", "c"} {
+		require.NoError(t, registry.RegisterBytes([]byte(`{"$id": "https://example.com/`+name+`"}`)))
+	}
+
+	loader := NewPolicyLoader(registry, Policy{MaxDepth: 1}, nil)
+
+	_, err := loader.Load("https://example.com/a", "")
+	require.NoError(t, err)
+
+	// The generator refers to remote parents by their qualified names.
+	_, err = loader.Load("https://example.com/b", "example.com/a")
+	require.NoError(t, err)
+
+	_, err = loader.Load("https://example.com/c", "example.com/b")
+	require.ErrorIs(t, err, ErrRefTooDeep)
+
+	_, err = loader.Load("https://example.com/c", "example.com/a")
+	require.NoError(t, err)
+}
+
+func TestMultiLoaderRestrict(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "a.json")
+	require.NoError(t, os.WriteFile(root, []byte(`{"title": "a"}`), 0o644))
+
+	registry := NewRegistry()
+	require.NoError(t, registry.RegisterBytes([]byte(`{"$id": "urn:example:b"}`)))
+
+	loader := NewDefaultMultiLoader(nil, nil)
+	loader[RefTypeRegistry] = registry
+	loader.Restrict(Policy{Roots: []string{dir}, Schemes: []string{"urn"}, MaxDepth: 1}, nil)
+
+	_, err := loader.Load(root, "")
+	require.NoError(t, err)
+
+	_, err = loader.Load("https://example.com/c.json", root)
+	require.ErrorIs(t, err, ErrSchemeNotAllowed)
+
+	// The loaders of all ref types share the depths of the documents.
+	_, err = loader.Load("urn:example:b", root)
+	require.NoError(t, err)
+
+	_, err = loader.Load(root, "urn:example:b")
+	require.ErrorIs(t, err, ErrRefTooDeep)
+}
@@ -17,10 +17,13 @@ var (
 type RefType string
 
 const (
-	RefTypeFile    RefType = "file"
-	RefTypeHTTP    RefType = "http"
-	RefTypeHTTPS   RefType = "https"
-	RefTypeUnknown RefType = "unknown"
+	RefTypeFile  RefType = "file"
+	RefTypeHTTP  RefType = "http"
+	RefTypeHTTPS RefType = "https"
+	// RefTypeRegistry is the type of URIs with any other scheme, such as
+	// urn: or tag:, which can only be resolved through a Registry.
+	RefTypeRegistry RefType = "registry"
+	RefTypeUnknown  RefType = "unknown"
 )
 
 func GetRefType(ref string) (RefType, error) {
@@ -40,6 +43,6 @@ func GetRefType(ref string) (RefType, error) {
 		return RefTypeFile, nil
 
 	default:
-		return RefTypeUnknown, fmt.Errorf("%w: %w", ErrGetRefType, ErrUnsupportedRefSchema)
+		return RefTypeRegistry, nil
 	}
 }
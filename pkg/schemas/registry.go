@@ -0,0 +1,210 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"sync"
+)
+
+var (
+	ErrSchemaNotRegistered = errors.New("schema is not registered")
+	ErrMissingSchemaID     = errors.New("schema has no absolute $id")
+	ErrDuplicateSchemaID   = errors.New("schema $id is already registered")
+)
+
+// registryExtensions are the extensions of the files RegisterFS registers.
+var registryExtensions = map[string]bool{".json": true, ".yaml": true, ".yml": true}
+
+// Registry is a loader that resolves URIs to schemas registered by their $id,
+// whatever the scheme of the URI, so that schemas identified by URNs or tags
+// can be referenced. Only the $id of a document is registered, not those of
+// the resources embedded in it.
+type Registry struct {
+	mu      sync.RWMutex
+	schemas map[string]*Schema
+}
+
+func NewRegistry() *Registry {
+	return &Registry{schemas: map[string]*Schema{}}
+}
+
+// Register adds a schema by its $id, which must be an absolute URI.
+func (r *Registry) Register(schema *Schema) error {
+	id, err := url.Parse(schema.ID)
+	if err != nil || !id.IsAbs() {
+		return fmt.Errorf("%w: %q", ErrMissingSchemaID, schema.ID)
+	}
+
+	uri := resourceURI(id)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.schemas[uri]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateSchemaID, uri)
+	}
+
+	r.schemas[uri] = schema
+
+	return nil
+}
+
+// RegisterBytes parses a JSON or YAML schema and registers it.
+func (r *Registry) RegisterBytes(data []byte) error {
+	schema, err := FromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	return r.Register(schema)
+}
+
+// RegisterFile parses a JSON or YAML schema file and registers it.
+func (r *Registry) RegisterFile(fileName string) error {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	schema, err := FromBytes(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	schema.SetSource(fileName, data)
+
+	if err := r.Register(schema); err != nil {
+		return fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	return nil
+}
+
+// RegisterFS registers every .json, .yaml and .yml file of fsys. Files that
+// have no absolute $id are skipped, since nothing could refer to them.
+func (r *Registry) RegisterFS(fsys fs.FS) error {
+	return fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !registryExtensions[path.Ext(name)] {
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return fmt.Errorf("failed to read schema: %w", err)
+		}
+
+		schema, err := FromBytes(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		if id, err := url.Parse(schema.ID); err != nil || !id.IsAbs() {
+			return nil
+		}
+
+		schema.SetSource(name, data)
+
+		if err := r.Register(schema); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		return nil
+	})
+}
+
+// Load returns the schema registered under uri, which is resolved against
+// parentURI when it is relative.
+func (r *Registry) Load(uri, parentURI string) (*Schema, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCannotParseRef, err)
+	}
+
+	schema, ok := r.lookup(u, parentURI)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrSchemaNotRegistered, resourceURI(u))
+	}
+
+	return schema, nil
+}
+
+func (r *Registry) lookup(u *url.URL, parentURI string) (*Schema, bool) {
+	if !u.IsAbs() {
+		if base, err := url.Parse(parentURI); err == nil && base.IsAbs() {
+			u = base.ResolveReference(u)
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schema, ok := r.schemas[resourceURI(u)]
+
+	return schema, ok
+}
+
+// UseRegistry makes l load every URI registered in registry from it, whatever
+// its scheme, before trying any other loader, so that a schema with an https
+// $id is not fetched once it is registered. URIs with schemes that no other
+// loader handles, such as urn:, are only loaded from the registry.
+func (l MultiLoader) UseRegistry(registry *Registry) {
+	for ref, loader := range l {
+		if ref != RefTypeRegistry {
+			l[ref] = &registryFirstLoader{registry: registry, loader: loader}
+		}
+	}
+
+	l[RefTypeRegistry] = registry
+}
+
+// registryFirstLoader loads the URIs known to a registry from it, and others
+// through loader.
+type registryFirstLoader struct {
+	registry *Registry
+	loader   Loader
+}
+
+func (l *registryFirstLoader) Load(uri, parentURI string) (*Schema, error) {
+	return l.LoadContext(context.Background(), uri, parentURI)
+}
+
+func (l *registryFirstLoader) LoadContext(ctx context.Context, uri, parentURI string) (*Schema, error) {
+	if u, err := url.Parse(uri); err == nil {
+		if schema, ok := l.registry.lookup(u, parentURI); ok {
+			return schema, nil
+		}
+	}
+
+	return LoadContext(ctx, l.loader, uri, parentURI)
+}
+
+func (l *registryFirstLoader) ResolveDocument(uri, parentURI string) (string, error) {
+	if u, err := url.Parse(uri); err == nil {
+		if _, ok := l.registry.lookup(u, parentURI); ok {
+			return "", errNoDocument
+		}
+	}
+
+	if loader, ok := l.loader.(DocumentLoader); ok {
+		return loader.ResolveDocument(uri, parentURI)
+	}
+
+	return "", errNoDocument
+}
+
+func (l *registryFirstLoader) ReadDocument(name string) ([]byte, error) {
+	if loader, ok := l.loader.(DocumentLoader); ok {
+		return loader.ReadDocument(name)
+	}
+
+	return nil, errNoDocument
+}
@@ -0,0 +1,122 @@
+package schemas
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRefType(t *testing.T) {
+	testCases := []struct {
+		ref  string
+		want RefType
+	}{
+		{ref: "schema.json", want: RefTypeFile},
+		{ref: "file:///tmp/schema.json", want: RefTypeFile},
+		{ref: "http://example.com/schema", want: RefTypeHTTP},
+		{ref: "https://example.com/schema", want: RefTypeHTTPS},
+		{ref: "urn:uuid:6e8bc430-9c3a-11d9-9669-0800200c9a66", want: RefTypeRegistry},
+		{ref: "tag:example.com,2024:schema", want: RefTypeRegistry},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.ref, func(t *testing.T) {
+			got, err := GetRefType(tC.ref)
+			require.NoError(t, err)
+			assert.Equal(t, tC.want, got)
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+
+	require.NoError(t, registry.RegisterBytes([]byte(`{"$id": "urn:example:a#", "title": "a"}`)))
+	require.NoError(t, registry.RegisterFS(fstest.MapFS{
+		"b.yaml":         {Data: []byte("$id: tag:example.com,2024:b\ntitle: b\n")},
+		"nested/c.json":  {Data: []byte(`{"$id": "https://example.com/c", "title": "c"}`)},
+		"anonymous.json": {Data: []byte(`{"title": "no id"}`)},
+		"README.md":      {Data: []byte("not a schema")},
+	}))
+
+	testCases := []struct {
+		uri       string
+		parentURI string
+		wantTitle string
+		wantErr   error
+	}{
+		{uri: "urn:example:a", wantTitle: "a"},
+		{uri: "urn:example:a#/$defs/x", wantTitle: "a"},
+		{uri: "tag:example.com,2024:b", wantTitle: "b"},
+		{uri: "c", parentURI: "https://example.com/root", wantTitle: "c"},
+		{uri: "urn:example:missing", wantErr: ErrSchemaNotRegistered},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.uri, func(t *testing.T) {
+			got, err := registry.Load(tC.uri, tC.parentURI)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.wantTitle, got.Title)
+		})
+	}
+
+	require.ErrorIs(t, registry.RegisterBytes([]byte(`{"$id": "urn:example:a"}`)), ErrDuplicateSchemaID)
+	require.ErrorIs(t, registry.RegisterBytes([]byte(`{"$id": "relative.json"}`)), ErrMissingSchemaID)
+}
+
+func TestMultiLoaderRegistry(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.RegisterBytes([]byte(`{"$id": "urn:example:a", "title": "a"}`)))
+
+	loader := NewDefaultMultiLoader(nil, nil)
+
+	_, err := loader.Load("urn:example:a", "")
+	require.ErrorIs(t, err, ErrUnsupportedRefFormat)
+
+	loader.UseRegistry(registry)
+
+	got, err := loader.Load("urn:example:a", "")
+	require.NoError(t, err)
+	assert.Equal(t, "a", got.Title)
+}
+
+func TestMultiLoaderRegistryTakesPrecedence(t *testing.T) {
+	var hits atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"title": "fetched"}`))
+	}))
+	defer server.Close()
+
+	registry := NewRegistry()
+	require.NoError(t, registry.RegisterBytes([]byte(`{"$id": "`+server.URL+`/a.json", "title": "registered"}`)))
+
+	loader := NewDefaultMultiLoader(nil, nil)
+	loader.UseRegistry(registry)
+
+	got, err := loader.Load(server.URL+"/a.json", "")
+	require.NoError(t, err)
+	assert.Equal(t, "registered", got.Title)
+
+	got, err = loader.Load("a.json", server.URL+"/b.json")
+	require.NoError(t, err)
+	assert.Equal(t, "registered", got.Title, "relative refs are resolved against their parent")
+	assert.Zero(t, hits.Load())
+
+	got, err = loader.Load(server.URL+"/other.json", "")
+	require.NoError(t, err)
+	assert.Equal(t, "fetched", got.Title, "unregistered URIs are still fetched")
+}
@@ -0,0 +1,155 @@
+package schemas
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Resource is a schema that can be referenced by its URI: a document with an
+// absolute $id, or a subschema of it with its own $id, as bundlers produce.
+type Resource struct {
+	// Pointer locates the resource within the document.
+	Pointer string
+	Type    *Type
+	// definitions are those of the type, or of the schema for a document.
+	definitions Definitions
+}
+
+// IndexResources returns the resources of the document by their absolute URI.
+// Relative $ids are resolved against the $id of the enclosing resource, and
+// so are the $refs inside embedded resources, which are made absolute so that
+// they can be resolved without knowing where they were found. The $refs of
+// the document itself are left as they are, to keep resolving relative ones
+// against its file.
+func (s *Schema) IndexResources() map[string]Resource {
+	resources := map[string]Resource{}
+
+	base, err := url.Parse(s.ID)
+	if err != nil || !base.IsAbs() {
+		base = nil
+	} else {
+		resources[resourceURI(base)] = Resource{Type: (*Type)(s.ObjectAsType), definitions: s.Definitions}
+	}
+
+	ix := &resourceIndexer{
+		resources: resources,
+		visited:   map[*Type]struct{}{},
+	}
+
+	if s.ObjectAsType != nil {
+		ix.walk((*Type)(s.ObjectAsType), "", base, false)
+	}
+
+	for _, name := range sortedTypeNames(s.Definitions) {
+		ix.walk(s.Definitions[name], "/$defs/"+escapeToken(name), base, false)
+	}
+
+	return resources
+}
+
+type resourceIndexer struct {
+	resources map[string]Resource
+	// visited guards against subschemas shared by several keywords.
+	visited map[*Type]struct{}
+}
+
+// walk indexes t and its subschemas. Embedded tells whether t is inside a
+// resource other than the document, so that its $refs must be made absolute.
+func (ix *resourceIndexer) walk(t *Type, pointer string, base *url.URL, embedded bool) {
+	if t == nil {
+		return
+	}
+
+	if _, ok := ix.visited[t]; ok {
+		return
+	}
+
+	ix.visited[t] = struct{}{}
+
+	if id, err := url.Parse(t.ID); err == nil && t.ID != "" {
+		switch {
+		case resourceURI(id) == "":
+			// Before draft 2019-09, an $id of just a fragment declared an anchor.
+			if t.Anchor == "" {
+				t.Anchor = id.Fragment
+			}
+
+		case base != nil || id.IsAbs():
+			if base != nil {
+				id = base.ResolveReference(id)
+			}
+
+			base, embedded = id, true
+			ix.resources[resourceURI(id)] = Resource{Pointer: pointer, Type: t, definitions: t.Definitions}
+		}
+	}
+
+	if embedded && t.Ref != "" {
+		if ref, err := url.Parse(t.Ref); err == nil {
+			t.Ref = base.ResolveReference(ref).String()
+		}
+	}
+
+	for _, sub := range t.subschemas() {
+		ix.walk(sub.t, pointer+sub.pointer, base, embedded)
+	}
+}
+
+// FindAnchor returns the pointer, relative to the resource, of the subschema
+// of the resource that declares the given $anchor.
+func (r Resource) FindAnchor(anchor string) (string, bool) {
+	if r.Type != nil && (r.Type.Anchor == anchor || r.Type.DynamicAnchor == anchor) {
+		return "", true
+	}
+
+	for _, name := range sortedTypeNames(r.definitions) {
+		def := r.definitions[name]
+		if def.Anchor == anchor || def.DynamicAnchor == anchor {
+			return "/$defs/" + escapeToken(name), true
+		}
+	}
+
+	return "", false
+}
+
+// ResourceURI returns the URI that a resource with the given absolute $id is
+// indexed by: without its fragment, which is empty for an $id.
+func ResourceURI(id string) (string, error) {
+	u, err := url.Parse(id)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrCannotParseRef, err)
+	}
+
+	return resourceURI(u), nil
+}
+
+func resourceURI(u *url.URL) string {
+	u = &url.URL{
+		Scheme:   u.Scheme,
+		Opaque:   u.Opaque,
+		User:     u.User,
+		Host:     u.Host,
+		Path:     u.Path,
+		RawPath:  u.RawPath,
+		RawQuery: u.RawQuery,
+	}
+
+	return u.String()
+}
+
+func escapeToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func sortedTypeNames(types map[string]*Type) []string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,67 @@
+package schemas
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexResources(t *testing.T) {
+	var schema Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"$id": "https://example.com/root.json#",
+		"type": "object",
+		"properties": {
+			"local": {"$ref": "#/$defs/a"}
+		},
+		"$defs": {
+			"a": {
+				"$id": "a.json",
+				"type": "object",
+				"properties": {
+					"self": {"$ref": "#/properties/b"},
+					"b": {"$ref": "b.json"}
+				},
+				"$defs": {
+					"nested": {"$id": "https://other.example/nested", "type": "string"}
+				}
+			},
+			"legacy": {"id": "#legacy", "type": "string"}
+		}
+	}`), &schema))
+
+	resources := schema.IndexResources()
+
+	uris := make([]string, 0, len(resources))
+	for uri := range resources {
+		uris = append(uris, uri)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"https://example.com/root.json",
+		"https://example.com/a.json",
+		"https://other.example/nested",
+	}, uris)
+
+	assert.Equal(t, "", resources["https://example.com/root.json"].Pointer)
+	assert.Equal(t, "/$defs/a", resources["https://example.com/a.json"].Pointer)
+	assert.Equal(t, "/$defs/a/$defs/nested", resources["https://other.example/nested"].Pointer)
+
+	a := schema.Definitions["a"]
+
+	// The $refs of embedded resources resolve against their $id, those of the
+	// document are left alone.
+	assert.Equal(t, "#/$defs/a", schema.Properties["local"].Ref)
+	assert.Equal(t, "https://example.com/a.json#/properties/b", a.Properties["self"].Ref)
+	assert.Equal(t, "https://example.com/b.json", a.Properties["b"].Ref)
+
+	// A fragment-only $id declares an anchor.
+	assert.Equal(t, "legacy", schema.Definitions["legacy"].Anchor)
+
+	pointer, ok := resources["https://example.com/root.json"].FindAnchor("legacy")
+	assert.True(t, ok)
+	assert.Equal(t, "/$defs/legacy", pointer)
+}
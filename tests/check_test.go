@@ -0,0 +1,77 @@
+package tests_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/walteh/schema2go/pkg/generator"
+)
+
+func TestCheckUpToDate(t *testing.T) {
+	t.Parallel()
+
+	cfg := basicConfig
+	cfg.DefaultOutputName = "primitives.go"
+
+	g, err := generator.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.DoFile("./data/core/primitives/primitives.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	stale, err := g.Check("./data/core/primitives")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stale) != 0 {
+		t.Fatalf("Expected no stale files, got %+v", stale)
+	}
+}
+
+func TestCheckStale(t *testing.T) {
+	t.Parallel()
+
+	golden, err := os.ReadFile("./data/core/primitives/primitives.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	edited := filepath.Join(dir, "edited.go")
+	missing := filepath.Join(dir, "missing.go")
+
+	if err := os.WriteFile(edited, []byte(strings.Replace(string(golden), "MyString", "MyStr", 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stale, err := generator.CheckSources(dir, map[string][]byte{
+		"edited.go":  golden,
+		"missing.go": golden,
+		"-":          golden,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stale) != 2 {
+		t.Fatalf("Expected 2 stale files, got %+v", stale)
+	}
+
+	if stale[0].FileName != edited || stale[0].Missing {
+		t.Errorf("Expected %s to be reported as out of date, got %+v", edited, stale[0])
+	}
+
+	if !strings.Contains(stale[0].Diff, "-\t// MyStr corresponds") || !strings.Contains(stale[0].Diff, "+\t// MyString corresponds") {
+		t.Errorf("Expected diff to show the edited field, got:\n%s", stale[0].Diff)
+	}
+
+	if stale[1].FileName != missing || !stale[1].Missing {
+		t.Errorf("Expected %s to be reported as missing, got %+v", missing, stale[1])
+	}
+}
@@ -0,0 +1,34 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package test
+
+type Address struct {
+	// Country corresponds to the JSON schema field "country".
+	Country *AddressCountry `json:"country,omitempty" yaml:"country,omitempty" mapstructure:"country,omitempty"`
+
+	// Street corresponds to the JSON schema field "street".
+	Street *string `json:"street,omitempty" yaml:"street,omitempty" mapstructure:"street,omitempty"`
+}
+
+type AddressCountry string
+
+type Customer struct {
+	// Address corresponds to the JSON schema field "address".
+	Address *Address `json:"address,omitempty" yaml:"address,omitempty" mapstructure:"address,omitempty"`
+
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+}
+
+type Legacy int
+
+type RefBundle struct {
+	// BillingAddress corresponds to the JSON schema field "billingAddress".
+	BillingAddress *Address `json:"billingAddress,omitempty" yaml:"billingAddress,omitempty" mapstructure:"billingAddress,omitempty"`
+
+	// Customer corresponds to the JSON schema field "customer".
+	Customer *Customer `json:"customer,omitempty" yaml:"customer,omitempty" mapstructure:"customer,omitempty"`
+
+	// Legacy corresponds to the JSON schema field "legacy".
+	Legacy *Legacy `json:"legacy,omitempty" yaml:"legacy,omitempty" mapstructure:"legacy,omitempty"`
+}
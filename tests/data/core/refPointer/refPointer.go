@@ -0,0 +1,49 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package test
+
+type AB string
+
+type CD float64
+
+type Outer interface{}
+
+type OuterAnyOf1 struct {
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty" yaml:"id,omitempty" mapstructure:"id,omitempty"`
+}
+
+type OuterInner struct {
+	// Value corresponds to the JSON schema field "value".
+	Value *int `json:"value,omitempty" yaml:"value,omitempty" mapstructure:"value,omitempty"`
+}
+
+type RefPointer struct {
+	// FirstSpec corresponds to the JSON schema field "firstSpec".
+	FirstSpec *SpecItems `json:"firstSpec,omitempty" yaml:"firstSpec,omitempty" mapstructure:"firstSpec,omitempty"`
+
+	// Nested corresponds to the JSON schema field "nested".
+	Nested *OuterInner `json:"nested,omitempty" yaml:"nested,omitempty" mapstructure:"nested,omitempty"`
+
+	// Slashed corresponds to the JSON schema field "slashed".
+	Slashed *AB `json:"slashed,omitempty" yaml:"slashed,omitempty" mapstructure:"slashed,omitempty"`
+
+	// Spaced corresponds to the JSON schema field "spaced".
+	Spaced *WithSpace `json:"spaced,omitempty" yaml:"spaced,omitempty" mapstructure:"spaced,omitempty"`
+
+	// Spec corresponds to the JSON schema field "spec".
+	Spec []SpecItems `json:"spec,omitempty" yaml:"spec,omitempty" mapstructure:"spec,omitempty"`
+
+	// Tilde corresponds to the JSON schema field "tilde".
+	Tilde *CD `json:"tilde,omitempty" yaml:"tilde,omitempty" mapstructure:"tilde,omitempty"`
+
+	// Variant corresponds to the JSON schema field "variant".
+	Variant *OuterAnyOf1 `json:"variant,omitempty" yaml:"variant,omitempty" mapstructure:"variant,omitempty"`
+}
+
+type SpecItems struct {
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+}
+
+type WithSpace bool
@@ -0,0 +1,51 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+
+type TupleItems struct {
+	// First corresponds to the JSON schema field "first".
+	First *TupleItems0 `json:"first,omitempty" yaml:"first,omitempty" mapstructure:"first,omitempty"`
+
+	// Point corresponds to the JSON schema field "point".
+	Point []float64 `json:"point,omitempty" yaml:"point,omitempty" mapstructure:"point,omitempty"`
+
+	// Tags corresponds to the JSON schema field "tags".
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty" mapstructure:"tags,omitempty"`
+
+	// Tuple corresponds to the JSON schema field "tuple".
+	Tuple []interface{} `json:"tuple,omitempty" yaml:"tuple,omitempty" mapstructure:"tuple,omitempty"`
+}
+
+type TupleItems0 string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *TupleItems) UnmarshalJSON(value []byte) error {
+	type Plain TupleItems
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if len(plain.Point) > 2 {
+		return fmt.Errorf("field %s length: must be <= %d", "point", 2)
+	}
+	*j = TupleItems(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *TupleItems) UnmarshalYAML(value *yaml.Node) error {
+	type Plain TupleItems
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if len(plain.Point) > 2 {
+		return fmt.Errorf("field %s length: must be <= %d", "point", 2)
+	}
+	*j = TupleItems(plain)
+	return nil
+}
@@ -0,0 +1,102 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "regexp"
+
+type Address struct {
+	// Street corresponds to the JSON schema field "street".
+	Street string `json:"street" yaml:"street" mapstructure:"street"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Address) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["street"]; raw != nil && !ok {
+		return fmt.Errorf("field street in Address: required")
+	}
+	type Plain Address
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Address(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Address) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["street"]; raw != nil && !ok {
+		return fmt.Errorf("field street in Address: required")
+	}
+	type Plain Address
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Address(plain)
+	return nil
+}
+
+type Anchor struct {
+	// Address corresponds to the JSON schema field "address".
+	Address *Address `json:"address,omitempty" yaml:"address,omitempty" mapstructure:"address,omitempty"`
+
+	// Node corresponds to the JSON schema field "node".
+	Node *Node `json:"node,omitempty" yaml:"node,omitempty" mapstructure:"node,omitempty"`
+}
+
+type Node struct {
+	// Value corresponds to the JSON schema field "value".
+	Value *string `json:"value,omitempty" yaml:"value,omitempty" mapstructure:"value,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Node) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	for key := range raw {
+		if matched, _ := regexp.MatchString(`^[a-z]+$`, key); !matched {
+			return fmt.Errorf("property name %q in Node: must match %s", key, `^[a-z]+$`)
+		}
+	}
+	type Plain Node
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Node(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Node) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	for key := range raw {
+		if matched, _ := regexp.MatchString(`^[a-z]+$`, key); !matched {
+			return fmt.Errorf("property name %q in Node: must match %s", key, `^[a-z]+$`)
+		}
+	}
+	type Plain Node
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Node(plain)
+	return nil
+}
@@ -0,0 +1,94 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+
+type PrefixItems struct {
+	// Point corresponds to the JSON schema field "point".
+	Point []float64 `json:"point,omitempty" yaml:"point,omitempty" mapstructure:"point,omitempty"`
+
+	// Tags corresponds to the JSON schema field "tags".
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty" mapstructure:"tags,omitempty"`
+
+	// Tuple corresponds to the JSON schema field "tuple".
+	Tuple []interface{} `json:"tuple,omitempty" yaml:"tuple,omitempty" mapstructure:"tuple,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *PrefixItems) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if items, ok := raw["tags"].([]interface{}); ok {
+		var matches []int
+		for i, item := range items {
+			matched := true
+			switch item.(type) {
+			case string:
+			default:
+				matched = false
+			}
+			if b, err := json.Marshal(item); err != nil || string(b) != "\"primary\"" {
+				matched = false
+			}
+			if matched {
+				matches = append(matches, i)
+			}
+		}
+		if len(matches) < 1 {
+			return fmt.Errorf("field %s: must contain >= %d items where %s, found %d at indices %v", "tags", 1, "item is \"primary\"", len(matches), matches)
+		}
+	}
+	type Plain PrefixItems
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if len(plain.Point) > 2 {
+		return fmt.Errorf("field %s length: must be <= %d", "point", 2)
+	}
+	*j = PrefixItems(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *PrefixItems) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if items, ok := raw["tags"].([]interface{}); ok {
+		var matches []int
+		for i, item := range items {
+			matched := true
+			switch item.(type) {
+			case string:
+			default:
+				matched = false
+			}
+			if b, err := json.Marshal(item); err != nil || string(b) != "\"primary\"" {
+				matched = false
+			}
+			if matched {
+				matches = append(matches, i)
+			}
+		}
+		if len(matches) < 1 {
+			return fmt.Errorf("field %s: must contain >= %d items where %s, found %d at indices %v", "tags", 1, "item is \"primary\"", len(matches), matches)
+		}
+	}
+	type Plain PrefixItems
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if len(plain.Point) > 2 {
+		return fmt.Errorf("field %s length: must be <= %d", "point", 2)
+	}
+	*j = PrefixItems(plain)
+	return nil
+}
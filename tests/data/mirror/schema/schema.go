@@ -0,0 +1,160 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "reflect"
+
+type Address struct {
+	// Country corresponds to the JSON schema field "country".
+	Country Country `json:"country" yaml:"country" mapstructure:"country"`
+
+	// Street corresponds to the JSON schema field "street".
+	Street string `json:"street" yaml:"street" mapstructure:"street"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Address) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["country"]; raw != nil && !ok {
+		return fmt.Errorf("field country in Address: required")
+	}
+	if _, ok := raw["street"]; raw != nil && !ok {
+		return fmt.Errorf("field street in Address: required")
+	}
+	type Plain Address
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Address(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Address) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["country"]; raw != nil && !ok {
+		return fmt.Errorf("field country in Address: required")
+	}
+	if _, ok := raw["street"]; raw != nil && !ok {
+		return fmt.Errorf("field street in Address: required")
+	}
+	type Plain Address
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Address(plain)
+	return nil
+}
+
+type Country string
+
+const CountryDE Country = "DE"
+const CountryFR Country = "FR"
+const CountryUS Country = "US"
+
+var enumValues_Country = []interface{}{
+	"DE",
+	"FR",
+	"US",
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Country) UnmarshalYAML(value *yaml.Node) error {
+	var v string
+	if err := value.Decode(&v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_Country {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_Country, v)
+	}
+	*j = Country(v)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Country) UnmarshalJSON(value []byte) error {
+	var v string
+	if err := json.Unmarshal(value, &v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_Country {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_Country, v)
+	}
+	*j = Country(v)
+	return nil
+}
+
+type Schema struct {
+	// Destination corresponds to the JSON schema field "destination".
+	Destination Address `json:"destination" yaml:"destination" mapstructure:"destination"`
+
+	// Origin corresponds to the JSON schema field "origin".
+	Origin Address `json:"origin" yaml:"origin" mapstructure:"origin"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Schema) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["destination"]; raw != nil && !ok {
+		return fmt.Errorf("field destination in Schema: required")
+	}
+	if _, ok := raw["origin"]; raw != nil && !ok {
+		return fmt.Errorf("field origin in Schema: required")
+	}
+	type Plain Schema
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Schema(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Schema) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["destination"]; raw != nil && !ok {
+		return fmt.Errorf("field destination in Schema: required")
+	}
+	if _, ok := raw["origin"]; raw != nil && !ok {
+		return fmt.Errorf("field origin in Schema: required")
+	}
+	type Plain Schema
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Schema(plain)
+	return nil
+}
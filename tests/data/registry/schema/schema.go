@@ -0,0 +1,149 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "regexp"
+
+// An ISO 3166-1 alpha-2 country code.
+type Country string
+
+type Phone struct {
+	// Number corresponds to the JSON schema field "number".
+	Number string `json:"number" yaml:"number" mapstructure:"number"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Phone) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["number"]; raw != nil && !ok {
+		return fmt.Errorf("field number in Phone: required")
+	}
+	type Plain Phone
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if matched, _ := regexp.MatchString(`^\+[0-9]+$`, string(plain.Number)); !matched {
+		return fmt.Errorf("field %s pattern match: must match %s", "Number", `^\+[0-9]+$`)
+	}
+	*j = Phone(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Phone) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["number"]; raw != nil && !ok {
+		return fmt.Errorf("field number in Phone: required")
+	}
+	type Plain Phone
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if matched, _ := regexp.MatchString(`^\+[0-9]+$`, string(plain.Number)); !matched {
+		return fmt.Errorf("field %s pattern match: must match %s", "Number", `^\+[0-9]+$`)
+	}
+	*j = Phone(plain)
+	return nil
+}
+
+type Schema struct {
+	// Address corresponds to the JSON schema field "address".
+	Address UrnExampleVendorAddress `json:"address" yaml:"address" mapstructure:"address"`
+
+	// Country corresponds to the JSON schema field "country".
+	Country *Country `json:"country,omitempty" yaml:"country,omitempty" mapstructure:"country,omitempty"`
+
+	// Phone corresponds to the JSON schema field "phone".
+	Phone *Phone `json:"phone,omitempty" yaml:"phone,omitempty" mapstructure:"phone,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Schema) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["address"]; raw != nil && !ok {
+		return fmt.Errorf("field address in Schema: required")
+	}
+	type Plain Schema
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Schema(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Schema) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["address"]; raw != nil && !ok {
+		return fmt.Errorf("field address in Schema: required")
+	}
+	type Plain Schema
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Schema(plain)
+	return nil
+}
+
+type UrnExampleVendorAddress struct {
+	// City corresponds to the JSON schema field "city".
+	City string `json:"city" yaml:"city" mapstructure:"city"`
+
+	// Street corresponds to the JSON schema field "street".
+	Street *string `json:"street,omitempty" yaml:"street,omitempty" mapstructure:"street,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *UrnExampleVendorAddress) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["city"]; raw != nil && !ok {
+		return fmt.Errorf("field city in UrnExampleVendorAddress: required")
+	}
+	type Plain UrnExampleVendorAddress
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = UrnExampleVendorAddress(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *UrnExampleVendorAddress) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["city"]; raw != nil && !ok {
+		return fmt.Errorf("field city in UrnExampleVendorAddress: required")
+	}
+	type Plain UrnExampleVendorAddress
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = UrnExampleVendorAddress(plain)
+	return nil
+}
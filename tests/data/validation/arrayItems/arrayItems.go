@@ -0,0 +1,306 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "slices"
+
+type ArrayItems struct {
+	// Ids corresponds to the JSON schema field "ids".
+	Ids Ids `json:"ids,omitempty" yaml:"ids,omitempty" mapstructure:"ids,omitempty"`
+
+	// Points corresponds to the JSON schema field "points".
+	Points []Point `json:"points,omitempty" yaml:"points,omitempty" mapstructure:"points,omitempty"`
+
+	// Ports corresponds to the JSON schema field "ports".
+	Ports []int `json:"ports,omitempty" yaml:"ports,omitempty" mapstructure:"ports,omitempty"`
+
+	// Tags corresponds to the JSON schema field "tags".
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty" mapstructure:"tags,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *ArrayItems) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if items, ok := raw["ports"].([]interface{}); ok {
+		var matches []int
+		for i, item := range items {
+			matched := true
+			switch item.(type) {
+			case int, int64, uint64, float64:
+				if f, ok := item.(float64); ok && f != float64(int64(f)) {
+					matched = false
+				}
+			default:
+				matched = false
+			}
+			if b, err := json.Marshal(item); err != nil || !slices.Contains([]string{"80", "443"}, string(b)) {
+				matched = false
+			}
+			if matched {
+				matches = append(matches, i)
+			}
+		}
+		if len(matches) < 1 {
+			return fmt.Errorf("field %s: must contain >= %d items where %s, found %d at indices %v", "ports", 1, "item is one of [80,443]", len(matches), matches)
+		}
+		if len(matches) > 2 {
+			return fmt.Errorf("field %s: must contain <= %d items where %s, found %d at indices %v", "ports", 2, "item is one of [80,443]", len(matches), matches)
+		}
+	}
+	if items, ok := raw["tags"].([]interface{}); ok {
+		var matches []int
+		for i, item := range items {
+			matched := true
+			switch item.(type) {
+			case string:
+			default:
+				matched = false
+			}
+			if b, err := json.Marshal(item); err != nil || string(b) != "\"primary\"" {
+				matched = false
+			}
+			if matched {
+				matches = append(matches, i)
+			}
+		}
+		if len(matches) < 1 {
+			return fmt.Errorf("field %s: must contain >= %d items where %s, found %d at indices %v", "tags", 1, "item is \"primary\"", len(matches), matches)
+		}
+	}
+	type Plain ArrayItems
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	seenPoints := map[string]int{}
+	for i, item := range plain.Points {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if first, ok := seenPoints[string(b)]; ok {
+			return fmt.Errorf("field %s: items %d and %d must be unique", "points", first, i)
+		}
+		seenPoints[string(b)] = i
+	}
+	seenTags := map[string]int{}
+	for i, item := range plain.Tags {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if first, ok := seenTags[string(b)]; ok {
+			return fmt.Errorf("field %s: items %d and %d must be unique", "tags", first, i)
+		}
+		seenTags[string(b)] = i
+	}
+	*j = ArrayItems(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *ArrayItems) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if items, ok := raw["ports"].([]interface{}); ok {
+		var matches []int
+		for i, item := range items {
+			matched := true
+			switch item.(type) {
+			case int, int64, uint64, float64:
+				if f, ok := item.(float64); ok && f != float64(int64(f)) {
+					matched = false
+				}
+			default:
+				matched = false
+			}
+			if b, err := json.Marshal(item); err != nil || !slices.Contains([]string{"80", "443"}, string(b)) {
+				matched = false
+			}
+			if matched {
+				matches = append(matches, i)
+			}
+		}
+		if len(matches) < 1 {
+			return fmt.Errorf("field %s: must contain >= %d items where %s, found %d at indices %v", "ports", 1, "item is one of [80,443]", len(matches), matches)
+		}
+		if len(matches) > 2 {
+			return fmt.Errorf("field %s: must contain <= %d items where %s, found %d at indices %v", "ports", 2, "item is one of [80,443]", len(matches), matches)
+		}
+	}
+	if items, ok := raw["tags"].([]interface{}); ok {
+		var matches []int
+		for i, item := range items {
+			matched := true
+			switch item.(type) {
+			case string:
+			default:
+				matched = false
+			}
+			if b, err := json.Marshal(item); err != nil || string(b) != "\"primary\"" {
+				matched = false
+			}
+			if matched {
+				matches = append(matches, i)
+			}
+		}
+		if len(matches) < 1 {
+			return fmt.Errorf("field %s: must contain >= %d items where %s, found %d at indices %v", "tags", 1, "item is \"primary\"", len(matches), matches)
+		}
+	}
+	type Plain ArrayItems
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	seenPoints := map[string]int{}
+	for i, item := range plain.Points {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if first, ok := seenPoints[string(b)]; ok {
+			return fmt.Errorf("field %s: items %d and %d must be unique", "points", first, i)
+		}
+		seenPoints[string(b)] = i
+	}
+	seenTags := map[string]int{}
+	for i, item := range plain.Tags {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if first, ok := seenTags[string(b)]; ok {
+			return fmt.Errorf("field %s: items %d and %d must be unique", "tags", first, i)
+		}
+		seenTags[string(b)] = i
+	}
+	*j = ArrayItems(plain)
+	return nil
+}
+
+type Ids []int
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Ids) UnmarshalJSON(value []byte) error {
+	type Plain Ids
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	seen := map[string]int{}
+	for i, item := range plain {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if first, ok := seen[string(b)]; ok {
+			return fmt.Errorf("field %s: items %d and %d must be unique", "Ids", first, i)
+		}
+		seen[string(b)] = i
+	}
+	var plainValue interface{}
+	if b, err := json.Marshal(plain); err != nil {
+		return err
+	} else if err := json.Unmarshal(b, &plainValue); err != nil {
+		return err
+	}
+	if items, ok := plainValue.([]interface{}); ok {
+		var matches []int
+		for i, item := range items {
+			matched := true
+			switch item.(type) {
+			case int, int64, uint64, float64:
+				if f, ok := item.(float64); ok && f != float64(int64(f)) {
+					matched = false
+				}
+			default:
+				matched = false
+			}
+			if b, err := json.Marshal(item); err != nil || string(b) != "1" {
+				matched = false
+			}
+			if matched {
+				matches = append(matches, i)
+			}
+		}
+		if len(matches) < 1 {
+			return fmt.Errorf("field %s: must contain >= %d items where %s, found %d at indices %v", "Ids", 1, "item is 1", len(matches), matches)
+		}
+		if len(matches) > 1 {
+			return fmt.Errorf("field %s: must contain <= %d items where %s, found %d at indices %v", "Ids", 1, "item is 1", len(matches), matches)
+		}
+	}
+	*j = Ids(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Ids) UnmarshalYAML(value *yaml.Node) error {
+	type Plain Ids
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	seen := map[string]int{}
+	for i, item := range plain {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if first, ok := seen[string(b)]; ok {
+			return fmt.Errorf("field %s: items %d and %d must be unique", "Ids", first, i)
+		}
+		seen[string(b)] = i
+	}
+	var plainValue interface{}
+	if b, err := json.Marshal(plain); err != nil {
+		return err
+	} else if err := json.Unmarshal(b, &plainValue); err != nil {
+		return err
+	}
+	if items, ok := plainValue.([]interface{}); ok {
+		var matches []int
+		for i, item := range items {
+			matched := true
+			switch item.(type) {
+			case int, int64, uint64, float64:
+				if f, ok := item.(float64); ok && f != float64(int64(f)) {
+					matched = false
+				}
+			default:
+				matched = false
+			}
+			if b, err := json.Marshal(item); err != nil || string(b) != "1" {
+				matched = false
+			}
+			if matched {
+				matches = append(matches, i)
+			}
+		}
+		if len(matches) < 1 {
+			return fmt.Errorf("field %s: must contain >= %d items where %s, found %d at indices %v", "Ids", 1, "item is 1", len(matches), matches)
+		}
+		if len(matches) > 1 {
+			return fmt.Errorf("field %s: must contain <= %d items where %s, found %d at indices %v", "Ids", 1, "item is 1", len(matches), matches)
+		}
+	}
+	*j = Ids(plain)
+	return nil
+}
+
+type Point struct {
+	// X corresponds to the JSON schema field "x".
+	X *float64 `json:"x,omitempty" yaml:"x,omitempty" mapstructure:"x,omitempty"`
+
+	// Y corresponds to the JSON schema field "y".
+	Y *float64 `json:"y,omitempty" yaml:"y,omitempty" mapstructure:"y,omitempty"`
+}
@@ -0,0 +1,258 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+
+type Const struct {
+	// Category corresponds to the JSON schema field "category".
+	Category *ConstKind `json:"category,omitempty" yaml:"category,omitempty" mapstructure:"category,omitempty"`
+
+	// Count corresponds to the JSON schema field "count".
+	Count int `json:"count" yaml:"count" mapstructure:"count"`
+
+	// Enabled corresponds to the JSON schema field "enabled".
+	Enabled *bool `json:"enabled,omitempty" yaml:"enabled,omitempty" mapstructure:"enabled,omitempty"`
+
+	// Kind corresponds to the JSON schema field "kind".
+	Kind string `json:"kind" yaml:"kind" mapstructure:"kind"`
+
+	// Point corresponds to the JSON schema field "point".
+	Point Point `json:"point,omitempty" yaml:"point,omitempty" mapstructure:"point,omitempty"`
+
+	// Ratio corresponds to the JSON schema field "ratio".
+	Ratio *float64 `json:"ratio,omitempty" yaml:"ratio,omitempty" mapstructure:"ratio,omitempty"`
+
+	// Tags corresponds to the JSON schema field "tags".
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty" mapstructure:"tags,omitempty"`
+
+	// Untyped corresponds to the JSON schema field "untyped".
+	Untyped *string `json:"untyped,omitempty" yaml:"untyped,omitempty" mapstructure:"untyped,omitempty"`
+
+	// Version corresponds to the JSON schema field "version".
+	Version *Version `json:"version,omitempty" yaml:"version,omitempty" mapstructure:"version,omitempty"`
+}
+
+const ConstCount int = 3
+const ConstEnabled bool = true
+
+type ConstKind string
+
+const ConstKind_1 string = "widget"
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *ConstKind) UnmarshalJSON(value []byte) error {
+	type Plain ConstKind
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if len(plain) > 16 {
+		return fmt.Errorf("field %s length: must be <= %d", "", 16)
+	}
+	*j = ConstKind(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *ConstKind) UnmarshalYAML(value *yaml.Node) error {
+	type Plain ConstKind
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if len(plain) > 16 {
+		return fmt.Errorf("field %s length: must be <= %d", "", 16)
+	}
+	*j = ConstKind(plain)
+	return nil
+}
+
+const ConstRatio float64 = 1.5
+const ConstUntyped string = "inferred"
+
+// MarshalJSON implements json.Marshaler; it fills in the fields that have a const
+// value.
+func (j Const) MarshalJSON() ([]byte, error) {
+	type Plain Const
+	plain := Plain(j)
+	plain.Count = ConstCount
+	constEnabled := ConstEnabled
+	plain.Enabled = &constEnabled
+	plain.Kind = ConstKind_1
+	constRatio := ConstRatio
+	plain.Ratio = &constRatio
+	constUntyped := ConstUntyped
+	plain.Untyped = &constUntyped
+	return json.Marshal(plain)
+}
+
+// MarshalYAML implements yaml.Marshaler; it fills in the fields that have a const
+// value.
+func (j Const) MarshalYAML() (interface{}, error) {
+	type Plain Const
+	plain := Plain(j)
+	plain.Count = ConstCount
+	constEnabled := ConstEnabled
+	plain.Enabled = &constEnabled
+	plain.Kind = ConstKind_1
+	constRatio := ConstRatio
+	plain.Ratio = &constRatio
+	constUntyped := ConstUntyped
+	plain.Untyped = &constUntyped
+	return plain, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Const) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["count"]; raw != nil && !ok {
+		return fmt.Errorf("field count in Const: required")
+	}
+	if _, ok := raw["kind"]; raw != nil && !ok {
+		return fmt.Errorf("field kind in Const: required")
+	}
+	if v, ok := raw["tags"]; ok {
+		if b, err := json.Marshal(v); err != nil || string(b) != "[\"a\",\"b\"]" {
+			return fmt.Errorf("field %s: must be %s", "tags", "[\"a\",\"b\"]")
+		}
+	}
+	type Plain Const
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if plain.Count != 3 {
+		return fmt.Errorf("field %s: must be %s", "count", "3")
+	}
+	if plain.Enabled != nil && *plain.Enabled != true {
+		return fmt.Errorf("field %s: must be %s", "enabled", "true")
+	}
+	if plain.Kind != "widget" {
+		return fmt.Errorf("field %s: must be %s", "kind", "\"widget\"")
+	}
+	if plain.Ratio != nil && *plain.Ratio != 1.5 {
+		return fmt.Errorf("field %s: must be %s", "ratio", "1.5")
+	}
+	if plain.Untyped != nil && *plain.Untyped != "inferred" {
+		return fmt.Errorf("field %s: must be %s", "untyped", "\"inferred\"")
+	}
+	*j = Const(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Const) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["count"]; raw != nil && !ok {
+		return fmt.Errorf("field count in Const: required")
+	}
+	if _, ok := raw["kind"]; raw != nil && !ok {
+		return fmt.Errorf("field kind in Const: required")
+	}
+	if v, ok := raw["tags"]; ok {
+		if b, err := json.Marshal(v); err != nil || string(b) != "[\"a\",\"b\"]" {
+			return fmt.Errorf("field %s: must be %s", "tags", "[\"a\",\"b\"]")
+		}
+	}
+	type Plain Const
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if plain.Count != 3 {
+		return fmt.Errorf("field %s: must be %s", "count", "3")
+	}
+	if plain.Enabled != nil && *plain.Enabled != true {
+		return fmt.Errorf("field %s: must be %s", "enabled", "true")
+	}
+	if plain.Kind != "widget" {
+		return fmt.Errorf("field %s: must be %s", "kind", "\"widget\"")
+	}
+	if plain.Ratio != nil && *plain.Ratio != 1.5 {
+		return fmt.Errorf("field %s: must be %s", "ratio", "1.5")
+	}
+	if plain.Untyped != nil && *plain.Untyped != "inferred" {
+		return fmt.Errorf("field %s: must be %s", "untyped", "\"inferred\"")
+	}
+	*j = Const(plain)
+	return nil
+}
+
+type Point map[string]interface{}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Point) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	type Plain Point
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if b, err := json.Marshal(raw); err != nil || string(b) != "{\"x\":1,\"y\":2}" {
+		return fmt.Errorf("field %s: must be %s", "Point", "{\"x\":1,\"y\":2}")
+	}
+	*j = Point(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Point) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	type Plain Point
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if b, err := json.Marshal(raw); err != nil || string(b) != "{\"x\":1,\"y\":2}" {
+		return fmt.Errorf("field %s: must be %s", "Point", "{\"x\":1,\"y\":2}")
+	}
+	*j = Point(plain)
+	return nil
+}
+
+type Version int
+
+const VersionValue Version = 2
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Version) UnmarshalYAML(value *yaml.Node) error {
+	type Plain Version
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if plain != 2 {
+		return fmt.Errorf("field %s: must be %s", "Version", "2")
+	}
+	*j = Version(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Version) UnmarshalJSON(value []byte) error {
+	type Plain Version
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if plain != 2 {
+		return fmt.Errorf("field %s: must be %s", "Version", "2")
+	}
+	*j = Version(plain)
+	return nil
+}
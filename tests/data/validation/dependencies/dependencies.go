@@ -0,0 +1,118 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "slices"
+
+type Dependencies struct {
+	// BillingAddress corresponds to the JSON schema field "billingAddress".
+	BillingAddress *string `json:"billingAddress,omitempty" yaml:"billingAddress,omitempty" mapstructure:"billingAddress,omitempty"`
+
+	// CreditCard corresponds to the JSON schema field "creditCard".
+	CreditCard *string `json:"creditCard,omitempty" yaml:"creditCard,omitempty" mapstructure:"creditCard,omitempty"`
+
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+
+	// Proxy corresponds to the JSON schema field "proxy".
+	Proxy *string `json:"proxy,omitempty" yaml:"proxy,omitempty" mapstructure:"proxy,omitempty"`
+
+	// ProxyPort corresponds to the JSON schema field "proxyPort".
+	ProxyPort *int `json:"proxyPort,omitempty" yaml:"proxyPort,omitempty" mapstructure:"proxyPort,omitempty"`
+
+	// ProxyScheme corresponds to the JSON schema field "proxyScheme".
+	ProxyScheme interface{} `json:"proxyScheme,omitempty" yaml:"proxyScheme,omitempty" mapstructure:"proxyScheme,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Dependencies) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["billingAddress"]; ok {
+		if _, ok := raw["name"]; !ok {
+			return fmt.Errorf("field %s: %s %s", "name", "required", "when billingAddress is set")
+		}
+	}
+	if _, ok := raw["creditCard"]; ok {
+		if _, ok := raw["billingAddress"]; !ok {
+			return fmt.Errorf("field %s: %s %s", "billingAddress", "required", "when creditCard is set")
+		}
+	}
+	if _, ok := raw["proxy"]; ok {
+		if _, ok := raw["proxyPort"]; !ok {
+			return fmt.Errorf("field %s: %s %s", "proxyPort", "required", "when proxy is set")
+		}
+		if v, ok := raw["proxyPort"]; ok {
+			switch v.(type) {
+			case int, int64, uint64, float64:
+				if f, ok := v.(float64); ok && f != float64(int64(f)) {
+					return fmt.Errorf("field %s: %s %s", "proxyPort", "must be an integer", "when proxy is set")
+				}
+			default:
+				return fmt.Errorf("field %s: %s %s", "proxyPort", "must be an integer", "when proxy is set")
+			}
+		}
+		if v, ok := raw["proxyScheme"]; ok {
+			if b, err := json.Marshal(v); err != nil || !slices.Contains([]string{"\"http\"", "\"https\""}, string(b)) {
+				return fmt.Errorf("field %s: %s %s", "proxyScheme", "must be one of [\"http\",\"https\"]", "when proxy is set")
+			}
+		}
+	}
+	type Plain Dependencies
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Dependencies(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Dependencies) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["billingAddress"]; ok {
+		if _, ok := raw["name"]; !ok {
+			return fmt.Errorf("field %s: %s %s", "name", "required", "when billingAddress is set")
+		}
+	}
+	if _, ok := raw["creditCard"]; ok {
+		if _, ok := raw["billingAddress"]; !ok {
+			return fmt.Errorf("field %s: %s %s", "billingAddress", "required", "when creditCard is set")
+		}
+	}
+	if _, ok := raw["proxy"]; ok {
+		if _, ok := raw["proxyPort"]; !ok {
+			return fmt.Errorf("field %s: %s %s", "proxyPort", "required", "when proxy is set")
+		}
+		if v, ok := raw["proxyPort"]; ok {
+			switch v.(type) {
+			case int, int64, uint64, float64:
+				if f, ok := v.(float64); ok && f != float64(int64(f)) {
+					return fmt.Errorf("field %s: %s %s", "proxyPort", "must be an integer", "when proxy is set")
+				}
+			default:
+				return fmt.Errorf("field %s: %s %s", "proxyPort", "must be an integer", "when proxy is set")
+			}
+		}
+		if v, ok := raw["proxyScheme"]; ok {
+			if b, err := json.Marshal(v); err != nil || !slices.Contains([]string{"\"http\"", "\"https\""}, string(b)) {
+				return fmt.Errorf("field %s: %s %s", "proxyScheme", "must be one of [\"http\",\"https\"]", "when proxy is set")
+			}
+		}
+	}
+	type Plain Dependencies
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Dependencies(plain)
+	return nil
+}
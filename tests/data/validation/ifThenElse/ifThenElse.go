@@ -0,0 +1,381 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "reflect"
+import "regexp"
+import "slices"
+
+type IfThenElse struct {
+	// Bucket corresponds to the JSON schema field "bucket".
+	Bucket *string `json:"bucket,omitempty" yaml:"bucket,omitempty" mapstructure:"bucket,omitempty"`
+
+	// Kind corresponds to the JSON schema field "kind".
+	Kind IfThenElseKind `json:"kind" yaml:"kind" mapstructure:"kind"`
+
+	// Mirror corresponds to the JSON schema field "mirror".
+	Mirror *Mirror `json:"mirror,omitempty" yaml:"mirror,omitempty" mapstructure:"mirror,omitempty"`
+
+	// Path corresponds to the JSON schema field "path".
+	Path *string `json:"path,omitempty" yaml:"path,omitempty" mapstructure:"path,omitempty"`
+
+	// Region corresponds to the JSON schema field "region".
+	Region *string `json:"region,omitempty" yaml:"region,omitempty" mapstructure:"region,omitempty"`
+}
+
+type IfThenElseKind string
+
+const IfThenElseKindGcs IfThenElseKind = "gcs"
+const IfThenElseKindLocal IfThenElseKind = "local"
+const IfThenElseKindS3 IfThenElseKind = "s3"
+
+var enumValues_IfThenElseKind = []interface{}{
+	"s3",
+	"gcs",
+	"local",
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *IfThenElseKind) UnmarshalJSON(value []byte) error {
+	var v string
+	if err := json.Unmarshal(value, &v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_IfThenElseKind {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_IfThenElseKind, v)
+	}
+	*j = IfThenElseKind(v)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *IfThenElseKind) UnmarshalYAML(value *yaml.Node) error {
+	var v string
+	if err := value.Decode(&v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_IfThenElseKind {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_IfThenElseKind, v)
+	}
+	*j = IfThenElseKind(v)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *IfThenElse) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["kind"]; raw != nil && !ok {
+		return fmt.Errorf("field kind in IfThenElse: required")
+	}
+	ifMatched := true
+	if _, ok := raw["kind"]; !ok {
+		ifMatched = false
+	}
+	if v, ok := raw["kind"]; ok {
+		switch v.(type) {
+		case string:
+		default:
+			ifMatched = false
+		}
+	}
+	if v, ok := raw["kind"]; ok {
+		if b, err := json.Marshal(v); err != nil || string(b) != "\"s3\"" {
+			ifMatched = false
+		}
+	}
+	if ifMatched {
+		if _, ok := raw["bucket"]; !ok {
+			return fmt.Errorf("field %s: %s %s", "bucket", "required", "when kind is \"s3\"")
+		}
+		if v, ok := raw["bucket"]; ok {
+			switch v.(type) {
+			case string:
+			default:
+				return fmt.Errorf("field %s: %s %s", "bucket", "must be a string", "when kind is \"s3\"")
+			}
+		}
+		if v, ok := raw["bucket"]; ok {
+			if s, ok := v.(string); ok && !(len(s) >= 3) {
+				return fmt.Errorf("field %s: %s %s", "bucket", "length must be >= 3", "when kind is \"s3\"")
+			}
+		}
+		if v, ok := raw["region"]; ok {
+			switch v.(type) {
+			case string:
+			default:
+				return fmt.Errorf("field %s: %s %s", "region", "must be a string", "when kind is \"s3\"")
+			}
+		}
+		if v, ok := raw["region"]; ok {
+			if s, ok := v.(string); ok {
+				if matched, _ := regexp.MatchString(`^[a-z]+-[a-z]+-[0-9]$`, s); !matched {
+					return fmt.Errorf("field %s: %s %s", "region", "must match ^[a-z]+-[a-z]+-[0-9]$", "when kind is \"s3\"")
+				}
+			}
+		}
+	} else {
+		if v, ok := raw["bucket"]; ok {
+			switch v.(type) {
+			case string:
+			default:
+				return fmt.Errorf("field %s: %s %s", "bucket", "must be a string", "unless kind is \"s3\"")
+			}
+		}
+	}
+	type Plain IfThenElse
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = IfThenElse(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *IfThenElse) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["kind"]; raw != nil && !ok {
+		return fmt.Errorf("field kind in IfThenElse: required")
+	}
+	ifMatched := true
+	if _, ok := raw["kind"]; !ok {
+		ifMatched = false
+	}
+	if v, ok := raw["kind"]; ok {
+		switch v.(type) {
+		case string:
+		default:
+			ifMatched = false
+		}
+	}
+	if v, ok := raw["kind"]; ok {
+		if b, err := json.Marshal(v); err != nil || string(b) != "\"s3\"" {
+			ifMatched = false
+		}
+	}
+	if ifMatched {
+		if _, ok := raw["bucket"]; !ok {
+			return fmt.Errorf("field %s: %s %s", "bucket", "required", "when kind is \"s3\"")
+		}
+		if v, ok := raw["bucket"]; ok {
+			switch v.(type) {
+			case string:
+			default:
+				return fmt.Errorf("field %s: %s %s", "bucket", "must be a string", "when kind is \"s3\"")
+			}
+		}
+		if v, ok := raw["bucket"]; ok {
+			if s, ok := v.(string); ok && !(len(s) >= 3) {
+				return fmt.Errorf("field %s: %s %s", "bucket", "length must be >= 3", "when kind is \"s3\"")
+			}
+		}
+		if v, ok := raw["region"]; ok {
+			switch v.(type) {
+			case string:
+			default:
+				return fmt.Errorf("field %s: %s %s", "region", "must be a string", "when kind is \"s3\"")
+			}
+		}
+		if v, ok := raw["region"]; ok {
+			if s, ok := v.(string); ok {
+				if matched, _ := regexp.MatchString(`^[a-z]+-[a-z]+-[0-9]$`, s); !matched {
+					return fmt.Errorf("field %s: %s %s", "region", "must match ^[a-z]+-[a-z]+-[0-9]$", "when kind is \"s3\"")
+				}
+			}
+		}
+	} else {
+		if v, ok := raw["bucket"]; ok {
+			switch v.(type) {
+			case string:
+			default:
+				return fmt.Errorf("field %s: %s %s", "bucket", "must be a string", "unless kind is \"s3\"")
+			}
+		}
+	}
+	type Plain IfThenElse
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = IfThenElse(plain)
+	return nil
+}
+
+type Mirror struct {
+	// DisabledReason corresponds to the JSON schema field "disabledReason".
+	DisabledReason *string `json:"disabledReason,omitempty" yaml:"disabledReason,omitempty" mapstructure:"disabledReason,omitempty"`
+
+	// Insecure corresponds to the JSON schema field "insecure".
+	Insecure *bool `json:"insecure,omitempty" yaml:"insecure,omitempty" mapstructure:"insecure,omitempty"`
+
+	// Priority corresponds to the JSON schema field "priority".
+	Priority *int `json:"priority,omitempty" yaml:"priority,omitempty" mapstructure:"priority,omitempty"`
+
+	// Url corresponds to the JSON schema field "url".
+	Url *string `json:"url,omitempty" yaml:"url,omitempty" mapstructure:"url,omitempty"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Mirror) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	ifMatched := true
+	if _, ok := raw["url"]; !ok {
+		ifMatched = false
+	}
+	if v, ok := raw["url"]; ok {
+		if s, ok := v.(string); ok {
+			if matched, _ := regexp.MatchString(`^https://`, s); !matched {
+				ifMatched = false
+			}
+		}
+	}
+	if !ifMatched {
+		if _, ok := raw["insecure"]; !ok {
+			return fmt.Errorf("field %s: %s %s", "insecure", "required", "unless url matches ^https://")
+		}
+		if v, ok := raw["insecure"]; ok {
+			switch v.(type) {
+			case bool:
+			default:
+				return fmt.Errorf("field %s: %s %s", "insecure", "must be a boolean", "unless url matches ^https://")
+			}
+		}
+		if v, ok := raw["insecure"]; ok {
+			if b, err := json.Marshal(v); err != nil || string(b) != "true" {
+				return fmt.Errorf("field %s: %s %s", "insecure", "must be true", "unless url matches ^https://")
+			}
+		}
+	}
+	ifMatched1 := true
+	if v, ok := raw["priority"]; ok {
+		switch v.(type) {
+		case int, int64, uint64, float64:
+			if f, ok := v.(float64); ok && f != float64(int64(f)) {
+				ifMatched1 = false
+			}
+		default:
+			ifMatched1 = false
+		}
+	}
+	if v, ok := raw["priority"]; ok {
+		if b, err := json.Marshal(v); err != nil || !slices.Contains([]string{"0"}, string(b)) {
+			ifMatched1 = false
+		}
+	}
+	if ifMatched1 {
+		if _, ok := raw["disabledReason"]; !ok {
+			return fmt.Errorf("field %s: %s %s", "disabledReason", "required", "when priority is one of [0]")
+		}
+		if v, ok := raw["disabledReason"]; ok {
+			switch v.(type) {
+			case string:
+			default:
+				return fmt.Errorf("field %s: %s %s", "disabledReason", "must be a string", "when priority is one of [0]")
+			}
+		}
+	}
+	type Plain Mirror
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Mirror(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Mirror) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	ifMatched := true
+	if _, ok := raw["url"]; !ok {
+		ifMatched = false
+	}
+	if v, ok := raw["url"]; ok {
+		if s, ok := v.(string); ok {
+			if matched, _ := regexp.MatchString(`^https://`, s); !matched {
+				ifMatched = false
+			}
+		}
+	}
+	if !ifMatched {
+		if _, ok := raw["insecure"]; !ok {
+			return fmt.Errorf("field %s: %s %s", "insecure", "required", "unless url matches ^https://")
+		}
+		if v, ok := raw["insecure"]; ok {
+			switch v.(type) {
+			case bool:
+			default:
+				return fmt.Errorf("field %s: %s %s", "insecure", "must be a boolean", "unless url matches ^https://")
+			}
+		}
+		if v, ok := raw["insecure"]; ok {
+			if b, err := json.Marshal(v); err != nil || string(b) != "true" {
+				return fmt.Errorf("field %s: %s %s", "insecure", "must be true", "unless url matches ^https://")
+			}
+		}
+	}
+	ifMatched1 := true
+	if v, ok := raw["priority"]; ok {
+		switch v.(type) {
+		case int, int64, uint64, float64:
+			if f, ok := v.(float64); ok && f != float64(int64(f)) {
+				ifMatched1 = false
+			}
+		default:
+			ifMatched1 = false
+		}
+	}
+	if v, ok := raw["priority"]; ok {
+		if b, err := json.Marshal(v); err != nil || !slices.Contains([]string{"0"}, string(b)) {
+			ifMatched1 = false
+		}
+	}
+	if ifMatched1 {
+		if _, ok := raw["disabledReason"]; !ok {
+			return fmt.Errorf("field %s: %s %s", "disabledReason", "required", "when priority is one of [0]")
+		}
+		if v, ok := raw["disabledReason"]; ok {
+			switch v.(type) {
+			case string:
+			default:
+				return fmt.Errorf("field %s: %s %s", "disabledReason", "must be a string", "when priority is one of [0]")
+			}
+		}
+	}
+	type Plain Mirror
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Mirror(plain)
+	return nil
+}
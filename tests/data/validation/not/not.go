@@ -0,0 +1,303 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "slices"
+
+type Codes []int
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Codes) UnmarshalJSON(value []byte) error {
+	type Plain Codes
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	var plainValue interface{}
+	if b, err := json.Marshal(plain); err != nil {
+		return err
+	} else if err := json.Unmarshal(b, &plainValue); err != nil {
+		return err
+	}
+	notMatched := true
+	var v interface{} = plainValue
+	switch v.(type) {
+	case []interface{}:
+	default:
+		notMatched = false
+	}
+	if b, err := json.Marshal(v); err != nil || string(b) != "[0]" {
+		notMatched = false
+	}
+	if notMatched {
+		return fmt.Errorf("%s: must not match %s", "Codes", "value is [0]")
+	}
+	*j = Codes(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Codes) UnmarshalYAML(value *yaml.Node) error {
+	type Plain Codes
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	var plainValue interface{}
+	if b, err := json.Marshal(plain); err != nil {
+		return err
+	} else if err := json.Unmarshal(b, &plainValue); err != nil {
+		return err
+	}
+	notMatched := true
+	var v interface{} = plainValue
+	switch v.(type) {
+	case []interface{}:
+	default:
+		notMatched = false
+	}
+	if b, err := json.Marshal(v); err != nil || string(b) != "[0]" {
+		notMatched = false
+	}
+	if notMatched {
+		return fmt.Errorf("%s: must not match %s", "Codes", "value is [0]")
+	}
+	*j = Codes(plain)
+	return nil
+}
+
+type Color string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Color) UnmarshalJSON(value []byte) error {
+	type Plain Color
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	notMatched := true
+	var v interface{} = string(plain)
+	switch v.(type) {
+	case string:
+	default:
+		notMatched = false
+	}
+	if b, err := json.Marshal(v); err != nil || string(b) != "\"transparent\"" {
+		notMatched = false
+	}
+	if notMatched {
+		return fmt.Errorf("%s: must not match %s", "Color", "value is \"transparent\"")
+	}
+	*j = Color(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Color) UnmarshalYAML(value *yaml.Node) error {
+	type Plain Color
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	notMatched := true
+	var v interface{} = string(plain)
+	switch v.(type) {
+	case string:
+	default:
+		notMatched = false
+	}
+	if b, err := json.Marshal(v); err != nil || string(b) != "\"transparent\"" {
+		notMatched = false
+	}
+	if notMatched {
+		return fmt.Errorf("%s: must not match %s", "Color", "value is \"transparent\"")
+	}
+	*j = Color(plain)
+	return nil
+}
+
+type Limits map[string]int
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Limits) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	type Plain Limits
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	notMatched := true
+	var v interface{} = raw
+	switch v.(type) {
+	case map[string]interface{}:
+	default:
+		notMatched = false
+	}
+	if b, err := json.Marshal(v); err != nil || string(b) != "{\"max\":0}" {
+		notMatched = false
+	}
+	if notMatched {
+		return fmt.Errorf("%s: must not match %s", "Limits", "value is {\"max\":0}")
+	}
+	*j = Limits(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Limits) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	type Plain Limits
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	notMatched := true
+	var v interface{} = raw
+	switch v.(type) {
+	case map[string]interface{}:
+	default:
+		notMatched = false
+	}
+	if b, err := json.Marshal(v); err != nil || string(b) != "{\"max\":0}" {
+		notMatched = false
+	}
+	if notMatched {
+		return fmt.Errorf("%s: must not match %s", "Limits", "value is {\"max\":0}")
+	}
+	*j = Limits(plain)
+	return nil
+}
+
+type Not struct {
+	// Codes corresponds to the JSON schema field "codes".
+	Codes Codes `json:"codes,omitempty" yaml:"codes,omitempty" mapstructure:"codes,omitempty"`
+
+	// Color corresponds to the JSON schema field "color".
+	Color *Color `json:"color,omitempty" yaml:"color,omitempty" mapstructure:"color,omitempty"`
+
+	// Limits corresponds to the JSON schema field "limits".
+	Limits Limits `json:"limits,omitempty" yaml:"limits,omitempty" mapstructure:"limits,omitempty"`
+
+	// Role corresponds to the JSON schema field "role".
+	Role *string `json:"role,omitempty" yaml:"role,omitempty" mapstructure:"role,omitempty"`
+
+	// Token corresponds to the JSON schema field "token".
+	Token *string `json:"token,omitempty" yaml:"token,omitempty" mapstructure:"token,omitempty"`
+
+	// Username corresponds to the JSON schema field "username".
+	Username *string `json:"username,omitempty" yaml:"username,omitempty" mapstructure:"username,omitempty"`
+
+	// Value corresponds to the JSON schema field "value".
+	Value interface{} `json:"value,omitempty" yaml:"value,omitempty" mapstructure:"value,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Not) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	notMatched := true
+	if _, ok := raw["username"]; !ok {
+		notMatched = false
+	}
+	if _, ok := raw["token"]; !ok {
+		notMatched = false
+	}
+	if notMatched {
+		return fmt.Errorf("%s: must not match %s", "Not", "username is set and token is set")
+	}
+	notMatched1 := true
+	if _, ok := raw["role"]; !ok {
+		notMatched1 = false
+	}
+	if v, ok := raw["role"]; ok {
+		if b, err := json.Marshal(v); err != nil || !slices.Contains([]string{"\"root\"", "\"admin\""}, string(b)) {
+			notMatched1 = false
+		}
+	}
+	if notMatched1 {
+		return fmt.Errorf("%s: must not match %s", "field role", "role is one of [\"root\",\"admin\"]")
+	}
+	notMatched2 := true
+	if _, ok := raw["value"]; !ok {
+		notMatched2 = false
+	}
+	if v, ok := raw["value"]; ok {
+		switch v.(type) {
+		case nil:
+		default:
+			notMatched2 = false
+		}
+	}
+	if notMatched2 {
+		return fmt.Errorf("%s: must not match %s", "field value", "value is null")
+	}
+	type Plain Not
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Not(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Not) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	notMatched := true
+	if _, ok := raw["username"]; !ok {
+		notMatched = false
+	}
+	if _, ok := raw["token"]; !ok {
+		notMatched = false
+	}
+	if notMatched {
+		return fmt.Errorf("%s: must not match %s", "Not", "username is set and token is set")
+	}
+	notMatched1 := true
+	if _, ok := raw["role"]; !ok {
+		notMatched1 = false
+	}
+	if v, ok := raw["role"]; ok {
+		if b, err := json.Marshal(v); err != nil || !slices.Contains([]string{"\"root\"", "\"admin\""}, string(b)) {
+			notMatched1 = false
+		}
+	}
+	if notMatched1 {
+		return fmt.Errorf("%s: must not match %s", "field role", "role is one of [\"root\",\"admin\"]")
+	}
+	notMatched2 := true
+	if _, ok := raw["value"]; !ok {
+		notMatched2 = false
+	}
+	if v, ok := raw["value"]; ok {
+		switch v.(type) {
+		case nil:
+		default:
+			notMatched2 = false
+		}
+	}
+	if notMatched2 {
+		return fmt.Errorf("%s: must not match %s", "field value", "value is null")
+	}
+	type Plain Not
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Not(plain)
+	return nil
+}
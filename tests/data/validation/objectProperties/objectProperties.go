@@ -0,0 +1,245 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import "github.com/go-viper/mapstructure/v2"
+import yaml "gopkg.in/yaml.v3"
+import "reflect"
+import "regexp"
+import "slices"
+import "strings"
+
+type ObjectProperties struct {
+	// Extra corresponds to the JSON schema field "extra".
+	Extra *ObjectPropertiesExtra `json:"extra,omitempty" yaml:"extra,omitempty" mapstructure:"extra,omitempty"`
+
+	// Labels corresponds to the JSON schema field "labels".
+	Labels ObjectPropertiesLabels `json:"labels,omitempty" yaml:"labels,omitempty" mapstructure:"labels,omitempty"`
+
+	// Limits corresponds to the JSON schema field "limits".
+	Limits ObjectPropertiesLimits `json:"limits,omitempty" yaml:"limits,omitempty" mapstructure:"limits,omitempty"`
+
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+}
+
+type ObjectPropertiesExtra struct {
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty" yaml:"id,omitempty" mapstructure:"id,omitempty"`
+
+	AdditionalProperties map[string]string `mapstructure:",remain"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *ObjectPropertiesExtra) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if len(raw) > 2 {
+		return fmt.Errorf("ObjectPropertiesExtra: number of properties must be <= %d", 2)
+	}
+	for key := range raw {
+		if len(key) < 2 {
+			return fmt.Errorf("property name %q in ObjectPropertiesExtra: length must be >= %d", key, 2)
+		}
+	}
+	type Plain ObjectPropertiesExtra
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if v, ok := raw[""]; !ok || v == nil {
+		plain.AdditionalProperties = map[string]string{}
+	}
+	st := reflect.TypeOf(Plain{})
+	for i := range st.NumField() {
+		delete(raw, st.Field(i).Name)
+		delete(raw, strings.Split(st.Field(i).Tag.Get("json"), ",")[0])
+	}
+	if err := mapstructure.Decode(raw, &plain.AdditionalProperties); err != nil {
+		return err
+	}
+	*j = ObjectPropertiesExtra(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *ObjectPropertiesExtra) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if len(raw) > 2 {
+		return fmt.Errorf("ObjectPropertiesExtra: number of properties must be <= %d", 2)
+	}
+	for key := range raw {
+		if len(key) < 2 {
+			return fmt.Errorf("property name %q in ObjectPropertiesExtra: length must be >= %d", key, 2)
+		}
+	}
+	type Plain ObjectPropertiesExtra
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if v, ok := raw[""]; !ok || v == nil {
+		plain.AdditionalProperties = map[string]string{}
+	}
+	st := reflect.TypeOf(Plain{})
+	for i := range st.NumField() {
+		delete(raw, st.Field(i).Name)
+		delete(raw, strings.Split(st.Field(i).Tag.Get("json"), ",")[0])
+	}
+	if err := mapstructure.Decode(raw, &plain.AdditionalProperties); err != nil {
+		return err
+	}
+	*j = ObjectPropertiesExtra(plain)
+	return nil
+}
+
+type ObjectPropertiesLabels map[string]string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *ObjectPropertiesLabels) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if raw != nil && len(raw) < 1 {
+		return fmt.Errorf("ObjectPropertiesLabels: number of properties must be >= %d", 1)
+	}
+	if len(raw) > 2 {
+		return fmt.Errorf("ObjectPropertiesLabels: number of properties must be <= %d", 2)
+	}
+	for key := range raw {
+		if matched, _ := regexp.MatchString(`^[a-z]+$`, key); !matched {
+			return fmt.Errorf("property name %q in ObjectPropertiesLabels: must match %s", key, `^[a-z]+$`)
+		}
+		if len(key) > 8 {
+			return fmt.Errorf("property name %q in ObjectPropertiesLabels: length must be <= %d", key, 8)
+		}
+	}
+	type Plain ObjectPropertiesLabels
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = ObjectPropertiesLabels(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *ObjectPropertiesLabels) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw != nil && len(raw) < 1 {
+		return fmt.Errorf("ObjectPropertiesLabels: number of properties must be >= %d", 1)
+	}
+	if len(raw) > 2 {
+		return fmt.Errorf("ObjectPropertiesLabels: number of properties must be <= %d", 2)
+	}
+	for key := range raw {
+		if matched, _ := regexp.MatchString(`^[a-z]+$`, key); !matched {
+			return fmt.Errorf("property name %q in ObjectPropertiesLabels: must match %s", key, `^[a-z]+$`)
+		}
+		if len(key) > 8 {
+			return fmt.Errorf("property name %q in ObjectPropertiesLabels: length must be <= %d", key, 8)
+		}
+	}
+	type Plain ObjectPropertiesLabels
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = ObjectPropertiesLabels(plain)
+	return nil
+}
+
+type ObjectPropertiesLimits map[string]int
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *ObjectPropertiesLimits) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	for key := range raw {
+		if !slices.Contains([]string{"cpu", "memory"}, key) {
+			return fmt.Errorf("property name %q in ObjectPropertiesLimits: must be one of %q", key, []string{"cpu", "memory"})
+		}
+	}
+	type Plain ObjectPropertiesLimits
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = ObjectPropertiesLimits(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *ObjectPropertiesLimits) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	for key := range raw {
+		if !slices.Contains([]string{"cpu", "memory"}, key) {
+			return fmt.Errorf("property name %q in ObjectPropertiesLimits: must be one of %q", key, []string{"cpu", "memory"})
+		}
+	}
+	type Plain ObjectPropertiesLimits
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = ObjectPropertiesLimits(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *ObjectProperties) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if raw != nil && len(raw) < 1 {
+		return fmt.Errorf("ObjectProperties: number of properties must be >= %d", 1)
+	}
+	if len(raw) > 3 {
+		return fmt.Errorf("ObjectProperties: number of properties must be <= %d", 3)
+	}
+	type Plain ObjectProperties
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = ObjectProperties(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *ObjectProperties) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw != nil && len(raw) < 1 {
+		return fmt.Errorf("ObjectProperties: number of properties must be >= %d", 1)
+	}
+	if len(raw) > 3 {
+		return fmt.Errorf("ObjectProperties: number of properties must be <= %d", 3)
+	}
+	type Plain ObjectProperties
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = ObjectProperties(plain)
+	return nil
+}
@@ -0,0 +1,465 @@
+// Code generated by schema2go. DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import "github.com/go-viper/mapstructure/v2"
+import yaml "gopkg.in/yaml.v3"
+import "reflect"
+import "regexp"
+import "slices"
+import "strings"
+
+type Labels map[string]string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Labels) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	for key, v := range raw {
+		matched := false
+		if ok, _ := regexp.MatchString(`^[a-z]+$`, key); ok {
+			matched = true
+			switch v.(type) {
+			case string:
+			default:
+				return fmt.Errorf("property %q in Labels: %s", key, "must be a string")
+			}
+		}
+		if !matched {
+			return fmt.Errorf("property %q in Labels: not allowed", key)
+		}
+	}
+	type Plain Labels
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Labels(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Labels) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	for key, v := range raw {
+		matched := false
+		if ok, _ := regexp.MatchString(`^[a-z]+$`, key); ok {
+			matched = true
+			switch v.(type) {
+			case string:
+			default:
+				return fmt.Errorf("property %q in Labels: %s", key, "must be a string")
+			}
+		}
+		if !matched {
+			return fmt.Errorf("property %q in Labels: not allowed", key)
+		}
+	}
+	type Plain Labels
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Labels(plain)
+	return nil
+}
+
+type PatternProperties struct {
+	// Extensions corresponds to the JSON schema field "extensions".
+	Extensions *PatternPropertiesExtensions `json:"extensions,omitempty" yaml:"extensions,omitempty" mapstructure:"extensions,omitempty"`
+
+	// Labels corresponds to the JSON schema field "labels".
+	Labels Labels `json:"labels,omitempty" yaml:"labels,omitempty" mapstructure:"labels,omitempty"`
+
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+
+	// NProperties holds the properties whose names match "^N_".
+	NProperties map[string]float64 `json:"-" yaml:"-" mapstructure:"-"`
+
+	// SProperties holds the properties whose names match "^S_".
+	SProperties map[string]string `json:"-" yaml:"-" mapstructure:"-"`
+}
+
+type PatternPropertiesExtensions struct {
+	// PatternProperties holds the properties whose names match "^x-".
+	PatternProperties map[string]PatternPropertiesExtensionsPatternPropertiesValue `json:"-" yaml:"-" mapstructure:"-"`
+
+	AdditionalProperties map[string]string `mapstructure:",remain"`
+}
+
+type PatternPropertiesExtensionsPatternPropertiesValue struct {
+	// Enabled corresponds to the JSON schema field "enabled".
+	Enabled bool `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *PatternPropertiesExtensionsPatternPropertiesValue) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["enabled"]; raw != nil && !ok {
+		return fmt.Errorf("field enabled in PatternPropertiesExtensionsPatternPropertiesValue: required")
+	}
+	type Plain PatternPropertiesExtensionsPatternPropertiesValue
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = PatternPropertiesExtensionsPatternPropertiesValue(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *PatternPropertiesExtensionsPatternPropertiesValue) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["enabled"]; raw != nil && !ok {
+		return fmt.Errorf("field enabled in PatternPropertiesExtensionsPatternPropertiesValue: required")
+	}
+	type Plain PatternPropertiesExtensionsPatternPropertiesValue
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = PatternPropertiesExtensionsPatternPropertiesValue(plain)
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler; it adds the properties whose names match
+// a pattern.
+func (j PatternPropertiesExtensions) MarshalYAML() (interface{}, error) {
+	type Plain PatternPropertiesExtensions
+	plain := Plain(j)
+	b, err := yaml.Marshal(plain)
+	if err != nil {
+		return nil, err
+	}
+	properties := map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &properties); err != nil {
+		return nil, err
+	}
+	for key, v := range j.PatternProperties {
+		properties[key] = v
+	}
+	return properties, nil
+}
+
+// MarshalJSON implements json.Marshaler; it adds the properties whose names match
+// a pattern.
+func (j PatternPropertiesExtensions) MarshalJSON() ([]byte, error) {
+	type Plain PatternPropertiesExtensions
+	plain := Plain(j)
+	b, err := json.Marshal(plain)
+	if err != nil {
+		return nil, err
+	}
+	properties := map[string]interface{}{}
+	if err := json.Unmarshal(b, &properties); err != nil {
+		return nil, err
+	}
+	for key, v := range j.PatternProperties {
+		properties[key] = v
+	}
+	return json.Marshal(properties)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *PatternPropertiesExtensions) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	for key, v := range raw {
+		if ok, _ := regexp.MatchString(`^x-`, key); ok {
+			switch v.(type) {
+			case map[string]interface{}:
+			default:
+				return fmt.Errorf("property %q in PatternPropertiesExtensions: %s", key, "must be an object")
+			}
+		}
+	}
+	type Plain PatternPropertiesExtensions
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	patternValues0 := map[string]interface{}{}
+	for key, v := range raw {
+		matched := false
+		if ok, _ := regexp.MatchString(`^x-`, key); ok {
+			patternValues0[key] = v
+			matched = true
+		}
+		if matched {
+			delete(raw, key)
+		}
+	}
+	if len(patternValues0) > 0 {
+		b, err := yaml.Marshal(patternValues0)
+		if err != nil {
+			return err
+		}
+		if err := yaml.Unmarshal(b, &plain.PatternProperties); err != nil {
+			return err
+		}
+	}
+	if v, ok := raw[""]; !ok || v == nil {
+		plain.AdditionalProperties = map[string]string{}
+	}
+	st := reflect.TypeOf(Plain{})
+	for i := range st.NumField() {
+		delete(raw, st.Field(i).Name)
+		delete(raw, strings.Split(st.Field(i).Tag.Get("json"), ",")[0])
+	}
+	if err := mapstructure.Decode(raw, &plain.AdditionalProperties); err != nil {
+		return err
+	}
+	*j = PatternPropertiesExtensions(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *PatternPropertiesExtensions) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	for key, v := range raw {
+		if ok, _ := regexp.MatchString(`^x-`, key); ok {
+			switch v.(type) {
+			case map[string]interface{}:
+			default:
+				return fmt.Errorf("property %q in PatternPropertiesExtensions: %s", key, "must be an object")
+			}
+		}
+	}
+	type Plain PatternPropertiesExtensions
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	patternValues0 := map[string]interface{}{}
+	for key, v := range raw {
+		matched := false
+		if ok, _ := regexp.MatchString(`^x-`, key); ok {
+			patternValues0[key] = v
+			matched = true
+		}
+		if matched {
+			delete(raw, key)
+		}
+	}
+	if len(patternValues0) > 0 {
+		b, err := json.Marshal(patternValues0)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(b, &plain.PatternProperties); err != nil {
+			return err
+		}
+	}
+	if v, ok := raw[""]; !ok || v == nil {
+		plain.AdditionalProperties = map[string]string{}
+	}
+	st := reflect.TypeOf(Plain{})
+	for i := range st.NumField() {
+		delete(raw, st.Field(i).Name)
+		delete(raw, strings.Split(st.Field(i).Tag.Get("json"), ",")[0])
+	}
+	if err := mapstructure.Decode(raw, &plain.AdditionalProperties); err != nil {
+		return err
+	}
+	*j = PatternPropertiesExtensions(plain)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler; it adds the properties whose names match
+// a pattern.
+func (j PatternProperties) MarshalJSON() ([]byte, error) {
+	type Plain PatternProperties
+	plain := Plain(j)
+	b, err := json.Marshal(plain)
+	if err != nil {
+		return nil, err
+	}
+	properties := map[string]interface{}{}
+	if err := json.Unmarshal(b, &properties); err != nil {
+		return nil, err
+	}
+	for key, v := range j.NProperties {
+		properties[key] = v
+	}
+	for key, v := range j.SProperties {
+		properties[key] = v
+	}
+	return json.Marshal(properties)
+}
+
+// MarshalYAML implements yaml.Marshaler; it adds the properties whose names match
+// a pattern.
+func (j PatternProperties) MarshalYAML() (interface{}, error) {
+	type Plain PatternProperties
+	plain := Plain(j)
+	b, err := yaml.Marshal(plain)
+	if err != nil {
+		return nil, err
+	}
+	properties := map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &properties); err != nil {
+		return nil, err
+	}
+	for key, v := range j.NProperties {
+		properties[key] = v
+	}
+	for key, v := range j.SProperties {
+		properties[key] = v
+	}
+	return properties, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *PatternProperties) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	for key, v := range raw {
+		matched := false
+		if ok, _ := regexp.MatchString(`^N_`, key); ok {
+			matched = true
+			switch v.(type) {
+			case int, int64, uint64, float64:
+			default:
+				return fmt.Errorf("property %q in PatternProperties: %s", key, "must be a number")
+			}
+		}
+		if ok, _ := regexp.MatchString(`^S_`, key); ok {
+			matched = true
+			switch v.(type) {
+			case string:
+			default:
+				return fmt.Errorf("property %q in PatternProperties: %s", key, "must be a string")
+			}
+			if s, ok := v.(string); ok && !(len(s) >= 2) {
+				return fmt.Errorf("property %q in PatternProperties: %s", key, "length must be >= 2")
+			}
+		}
+		if !matched && !slices.Contains([]string{"extensions", "labels", "name"}, key) {
+			return fmt.Errorf("property %q in PatternProperties: not allowed", key)
+		}
+	}
+	type Plain PatternProperties
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	patternValues0 := map[string]interface{}{}
+	patternValues1 := map[string]interface{}{}
+	for key, v := range raw {
+		if ok, _ := regexp.MatchString(`^N_`, key); ok {
+			patternValues0[key] = v
+		}
+		if ok, _ := regexp.MatchString(`^S_`, key); ok {
+			patternValues1[key] = v
+		}
+	}
+	if len(patternValues0) > 0 {
+		b, err := json.Marshal(patternValues0)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(b, &plain.NProperties); err != nil {
+			return err
+		}
+	}
+	if len(patternValues1) > 0 {
+		b, err := json.Marshal(patternValues1)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(b, &plain.SProperties); err != nil {
+			return err
+		}
+	}
+	*j = PatternProperties(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *PatternProperties) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	for key, v := range raw {
+		matched := false
+		if ok, _ := regexp.MatchString(`^N_`, key); ok {
+			matched = true
+			switch v.(type) {
+			case int, int64, uint64, float64:
+			default:
+				return fmt.Errorf("property %q in PatternProperties: %s", key, "must be a number")
+			}
+		}
+		if ok, _ := regexp.MatchString(`^S_`, key); ok {
+			matched = true
+			switch v.(type) {
+			case string:
+			default:
+				return fmt.Errorf("property %q in PatternProperties: %s", key, "must be a string")
+			}
+			if s, ok := v.(string); ok && !(len(s) >= 2) {
+				return fmt.Errorf("property %q in PatternProperties: %s", key, "length must be >= 2")
+			}
+		}
+		if !matched && !slices.Contains([]string{"extensions", "labels", "name"}, key) {
+			return fmt.Errorf("property %q in PatternProperties: not allowed", key)
+		}
+	}
+	type Plain PatternProperties
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	patternValues0 := map[string]interface{}{}
+	patternValues1 := map[string]interface{}{}
+	for key, v := range raw {
+		if ok, _ := regexp.MatchString(`^N_`, key); ok {
+			patternValues0[key] = v
+		}
+		if ok, _ := regexp.MatchString(`^S_`, key); ok {
+			patternValues1[key] = v
+		}
+	}
+	if len(patternValues0) > 0 {
+		b, err := yaml.Marshal(patternValues0)
+		if err != nil {
+			return err
+		}
+		if err := yaml.Unmarshal(b, &plain.NProperties); err != nil {
+			return err
+		}
+	}
+	if len(patternValues1) > 0 {
+		b, err := yaml.Marshal(patternValues1)
+		if err != nil {
+			return err
+		}
+		if err := yaml.Unmarshal(b, &plain.SProperties); err != nil {
+			return err
+		}
+	}
+	*j = PatternProperties(plain)
+	return nil
+}
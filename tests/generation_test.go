@@ -1,6 +1,7 @@
 package tests_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -8,11 +9,13 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/walteh/schema2go/pkg/generator"
+	"github.com/walteh/schema2go/pkg/schemas"
 )
 
 var (
@@ -87,6 +90,36 @@ func TestCrossPackageNoOutput(t *testing.T) {
 	testExampleFile(t, cfg, "./data/crossPackageNoOutput/schema/schema.json")
 }
 
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry := schemas.NewRegistry()
+	if err := registry.RegisterFS(os.DirFS("./data/registry/vendor")); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := schemas.NewDefaultMultiLoader(basicConfig.ResolveExtensions, basicConfig.YAMLExtensions)
+	loader.UseRegistry(registry)
+
+	cfg := basicConfig
+	cfg.Loader = loader
+	testExampleFile(t, cfg, "./data/registry/schema/schema.json")
+}
+
+func TestMirror(t *testing.T) {
+	t.Parallel()
+
+	loader := schemas.NewDefaultMultiLoader(basicConfig.ResolveExtensions, basicConfig.YAMLExtensions)
+	loader.Mirror(
+		map[string]string{"https://schemas.example.com/": "./vendor/"},
+		schemas.NewFSLoader(os.DirFS("./data/mirror"), basicConfig.ResolveExtensions, basicConfig.YAMLExtensions),
+	)
+
+	cfg := basicConfig
+	cfg.Loader = loader
+	testExampleFile(t, cfg, "./data/mirror/schema/schema.json")
+}
+
 func TestBooleanAsSchema(t *testing.T) {
 	t.Parallel()
 
@@ -186,6 +219,77 @@ func TestSchemaExtensions(t *testing.T) {
 	testExamples(t, basicConfig, "./data/schemaExtensions")
 }
 
+func TestDraft2020(t *testing.T) {
+	t.Parallel()
+
+	testExamples(t, basicConfig, "./data/draft2020")
+}
+
+// Generators that share a loader prepare their own copy of the schemas it
+// returns, so that they neither race nor see each other's changes.
+func TestSharedLoader(t *testing.T) {
+	t.Parallel()
+
+	cfg := basicConfig
+	cfg.Loader = schemas.NewDefaultCacheLoader(cfg.ResolveExtensions, cfg.YAMLExtensions)
+	// Logging would order the generators, and hide their races.
+	cfg.Warner = func(string) {}
+
+	fileNames := []string{
+		"./data/validation/ifThenElse/ifThenElse.json",
+		"./data/draft2020/unevaluatedProperties/unevaluatedProperties.json",
+	}
+
+	var wg sync.WaitGroup
+
+	start := make(chan struct{})
+	errs := make(chan error, 8)
+
+	for i := range 8 {
+		fileName := fileNames[i%len(fileNames)]
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			<-start
+
+			g, err := generator.New(cfg)
+			if err != nil {
+				errs <- err
+
+				return
+			}
+
+			if err := g.DoFile(fileName); err != nil {
+				errs <- err
+
+				return
+			}
+
+			golden, err := os.ReadFile(strings.TrimSuffix(fileName, ".json") + ".go")
+			if err != nil {
+				errs <- err
+
+				return
+			}
+
+			if source := g.Sources()["-"]; string(source) != string(golden) {
+				errs <- fmt.Errorf("%s: contents differ from the golden file:\n%s", fileName, source)
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
 func testExamples(t *testing.T, cfg generator.Config, dataDir string) {
 	t.Helper()
 
@@ -338,3 +442,55 @@ func mustAbs(s string) string {
 
 	return result
 }
+
+func TestOneOfConstantNames(t *testing.T) {
+	t.Parallel()
+
+	kind := func(value string) string {
+		return `{"type": "object", "properties": {"kind": {"type": ["integer", "string", "null"], "const": ` + value + `}}, "required": ["kind"]}`
+	}
+
+	schema := `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type": "object",
+		"oneOf": [
+			{"$ref": "#/definitions/A"},
+			{"$ref": "#/definitions/B"},
+			{"$ref": "#/definitions/C"},
+			{"$ref": "#/definitions/D"}
+		],
+		"definitions": {
+			"A": ` + kind(`1`) + `,
+			"B": ` + kind(`"1"`) + `,
+			"C": ` + kind(`null`) + `,
+			"D": ` + kind(`"1"`) + `
+		}
+	}`
+
+	g, err := generator.New(basicConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.DoBytes(context.Background(), "shape.json", []byte(schema)); err != nil {
+		t.Fatal(err)
+	}
+
+	source := string(g.Sources()["-"])
+
+	for _, want := range []string{
+		"const ShapeKindNumber1 ShapeKind = 1",
+		`const ShapeKindA1 ShapeKind = "1"`,
+		"const ShapeKindNull ShapeKind = nil",
+		"func (j *B) Kind() ShapeKind { return ShapeKindA1 }",
+		"func (j *D) Kind() ShapeKind { return ShapeKindA1 }",
+	} {
+		if !strings.Contains(source, want) {
+			t.Errorf("Expected source to contain %q:\n%s", want, source)
+		}
+	}
+
+	if strings.Contains(source, "<nil>") {
+		t.Errorf("Expected no constant named after a formatted nil:\n%s", source)
+	}
+}
@@ -0,0 +1,91 @@
+package tests_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/walteh/schema2go/pkg/generator"
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	const fileName = "./data/lint/lint.yaml"
+
+	schema, err := schemas.FromYAMLFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at := func(pointer string, line, column int) schemas.Origin {
+		return schemas.Origin{File: fileName, Pointer: pointer, Line: line, Column: column}
+	}
+
+	// The keywords of the declared types ids and origin are validated, so
+	// they have no findings.
+	want := []generator.Finding{
+		{
+			Origin:  at("/$defs/unused/maxLenght", 96, 5),
+			Rule:    generator.RuleMisspelledKeyword,
+			Message: `unknown keyword "maxLenght"; did you mean "maxLength"?`,
+		},
+		{
+			Origin:  at("/properties/both", 21, 3),
+			Rule:    generator.RuleConflictingAllOf,
+			Message: `allOf/0 and allOf/1 declare property "id" with different types`,
+		},
+		{
+			Origin:  at("/properties/count", 10, 3),
+			Rule:    generator.RuleInapplicableKeyword,
+			Message: "maxLength does not apply to type integer and is ignored",
+		},
+		{
+			Origin:  at("/properties/grid", 47, 3),
+			Rule:    generator.RuleUnhonoredKeyword,
+			Message: "items uses keywords contains, which are not validated inline; declare it in $defs and $ref it to validate them",
+		},
+		{
+			Origin: at("/properties/labels", 41, 3),
+			Rule:   generator.RuleUnhonoredKeyword,
+			Message: "additionalProperties uses keywords not, which are not validated inline; " +
+				"declare it in $defs and $ref it to validate them",
+		},
+		{
+			Origin:  at("/properties/names", 35, 3),
+			Rule:    generator.RuleUnhonoredKeyword,
+			Message: "items uses keywords not, which are not validated inline; declare it in $defs and $ref it to validate them",
+		},
+		{
+			Origin:  at("/properties/odd", 31, 3),
+			Rule:    generator.RuleUnhonoredKeyword,
+			Message: "then and else are ignored without if",
+		},
+		{
+			Origin: at("/properties/shape", 13, 3),
+			Rule:   generator.RuleAmbiguousOneOf,
+			Message: "oneOf/0 and oneOf/1 may accept the same values, " +
+				"and no property with a const or enum tells the branches apart",
+		},
+		{
+			Origin:  at("/properties/tags", 4, 3),
+			Rule:    generator.RuleUnhonoredKeyword,
+			Message: "items uses keywords minLength, which are not validated inline; declare it in $defs and $ref it to validate them",
+		},
+		{
+			Origin:  at("/$defs/unused", 92, 3),
+			Rule:    generator.RuleUnhonoredKeyword,
+			Message: "media is not supported and is ignored",
+		},
+		{
+			Origin:  at("/$defs/unused", 92, 3),
+			Rule:    generator.RuleUnusedDefinition,
+			Message: "definition is never referenced",
+		},
+	}
+
+	if diff := cmp.Diff(want, generator.Lint(fileName, schema)); diff != "" {
+		t.Errorf("Unexpected findings (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,130 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/walteh/schema2go/pkg/generator"
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+func TestErrorsIncludeOriginAndRefChain(t *testing.T) {
+	t.Parallel()
+
+	// Definitions are generated in name order, so the invalid type is first
+	// reached from "a" through both refs.
+	data := []byte(`{
+  "type": "object",
+  "$defs": {
+    "a": {
+      "type": "object",
+      "properties": {
+        "b": {"$ref": "#/$defs/b"}
+      }
+    },
+    "b": {
+      "type": "object",
+      "properties": {
+        "c": {"$ref": "#/$defs/c"}
+      }
+    },
+    "c": {"type": "widget"}
+  }
+}`)
+
+	g, err := generator.New(basicConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = g.DoBytes(context.Background(), "memory/origin.json", data)
+
+	var schemaErr *generator.SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("Expected a SchemaError, got %v", err)
+	}
+
+	want := schemas.Origin{File: "memory/origin.json", Pointer: "/$defs/c", Line: 16, Column: 5}
+	if schemaErr.Origin != want {
+		t.Errorf("Expected origin %v, got %v", want, schemaErr.Origin)
+	}
+
+	wantRefs := []generator.RefStep{
+		{Ref: "#/$defs/b", Origin: schemas.Origin{File: "memory/origin.json", Pointer: "/$defs/a/properties/b", Line: 7, Column: 9}},
+		{Ref: "#/$defs/c", Origin: schemas.Origin{File: "memory/origin.json", Pointer: "/$defs/b/properties/c", Line: 13, Column: 9}},
+	}
+
+	if len(schemaErr.Refs) != len(wantRefs) {
+		t.Fatalf("Expected refs %v, got %v", wantRefs, schemaErr.Refs)
+	}
+
+	for i := range wantRefs {
+		if schemaErr.Refs[i] != wantRefs[i] {
+			t.Errorf("Expected ref %d to be %v, got %v", i, wantRefs[i], schemaErr.Refs[i])
+		}
+	}
+
+	if !strings.HasPrefix(err.Error(), `memory/origin.json:16:5 (#/$defs/c): `) ||
+		!strings.HasSuffix(err.Error(), `(reached through $ref "#/$defs/b" at memory/origin.json:7:9 (#/$defs/a/properties/b), `+
+			`$ref "#/$defs/c" at memory/origin.json:13:9 (#/$defs/b/properties/c))`) {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func TestWarningsIncludeOrigin(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`$schema: http://json-schema.org/draft-07/schema#
+type: object
+properties:
+  list:
+    type: array
+    prefixItems:
+      - type: string
+      - type: integer
+`)
+
+	var (
+		mu       sync.Mutex
+		warnings []string
+	)
+
+	cfg := basicConfig
+	cfg.Warner = func(message string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		warnings = append(warnings, message)
+	}
+
+	g, err := generator.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.DoBytes(context.Background(), "memory/warnings.yaml", data); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"memory/warnings.yaml:4:3 (#/properties/list): prefixItems is not a keyword of draft-07; " +
+			"it is interpreted as in draft 2020-12",
+		"memory/warnings.yaml:4:3 (#/properties/list): prefixItems have differing types; " +
+			"items will be represented as interface{}",
+	} {
+		found := false
+
+		for _, warning := range warnings {
+			if warning == want {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Errorf("Expected warning %q, got %q", want, warnings)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package tests_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/walteh/schema2go/pkg/generator"
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+func TestPolicyRefusesRefsOutsideRoots(t *testing.T) {
+	t.Parallel()
+
+	cfg := basicConfig
+	cfg.Loader = schemas.NewPolicyLoader(
+		schemas.NewDefaultMultiLoader(cfg.ResolveExtensions, cfg.YAMLExtensions),
+		schemas.Policy{Roots: []string{"./data/crossPackage/schema"}},
+		cfg.ResolveExtensions,
+	)
+
+	g, err := generator.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = g.DoFile("./data/crossPackage/schema/schema.json")
+
+	var policyErr *schemas.PolicyError
+	if !errors.As(err, &policyErr) || !errors.Is(err, schemas.ErrOutsideRoots) {
+		t.Fatalf("Expected the $ref to be refused, got %v", err)
+	}
+
+	if policyErr.Ref != "../other/other.json" {
+		t.Errorf("Expected the refused $ref to be ../other/other.json, got %q", policyErr.Ref)
+	}
+
+	if want := `$ref "../other/other.json#/$defs/Thing"`; !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected error to contain %q, got %v", want, err)
+	}
+}
@@ -0,0 +1,163 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/walteh/schema2go/pkg/generator"
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+func TestDoBytesDetectsYAML(t *testing.T) {
+	t.Parallel()
+
+	jsonData, err := os.ReadFile("./data/core/primitives/primitives.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	yamlData := []byte(`
+$schema: http://json-schema.org/draft-04/schema#
+id: https://example.com/primitives
+type: object
+properties:
+  myString: {type: string}
+  myNumber: {type: number}
+  myInteger: {type: integer}
+  myBoolean: {type: boolean}
+  myNull: {type: "null"}
+`)
+
+	golden, err := os.ReadFile("./data/core/primitives/primitives.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, data := range map[string][]byte{"json": jsonData, "yaml": yamlData} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			g, err := generator.New(basicConfig)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The logical URI has no extension, so only the content tells the
+			// formats apart.
+			if err := g.DoReader(context.Background(), "memory/primitives", bytes.NewReader(data)); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := string(g.Sources()["-"]); got != string(golden) {
+				t.Errorf("Expected output to match %s, got:\n%s", "primitives.go", got)
+			}
+		})
+	}
+}
+
+func TestDoBytesResolvesRefsAgainstURI(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("./data/core/refExternalFile/refExternalFile.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden, err := os.ReadFile("./data/core/refExternalFile/refExternalFile.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := generator.New(basicConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing exists at this location; only its directory matters for resolving
+	// "../ref/ref.json". The extension does not make the content YAML either.
+	if err := g.DoBytes(context.Background(), "./data/core/refExternalFile/refExternalFile.yaml", data); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := string(g.Sources()["-"]); got != string(golden) {
+		t.Errorf("Expected output to match refExternalFile.go, got:\n%s", got)
+	}
+}
+
+func TestDoBytesHonorsContext(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("./data/core/refExternalFile/refExternalFile.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := generator.New(basicConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := g.DoBytes(ctx, "./data/core/refExternalFile/refExternalFile.json", data); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestDoBytesHonorsContextDuringLoad(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("./data/core/refExternalFile/refExternalFile.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loader := &blockingLoader{loading: make(chan struct{})}
+
+	config := basicConfig
+	config.Loader = loader
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-loader.loading
+		cancel()
+	}()
+
+	if err := g.DoBytes(ctx, "./data/core/refExternalFile/refExternalFile.json", data); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+// blockingLoader is a loader whose loads wait for their context to be done,
+// as those of a server that does not respond do.
+type blockingLoader struct {
+	loading chan struct{}
+	once    sync.Once
+}
+
+func (l *blockingLoader) Load(uri, parentURI string) (*schemas.Schema, error) {
+	return nil, fmt.Errorf("%s loaded without a context", uri)
+}
+
+func (l *blockingLoader) LoadContext(ctx context.Context, uri, parentURI string) (*schemas.Schema, error) {
+	l.once.Do(func() {
+		close(l.loading)
+	})
+
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
@@ -9,6 +9,8 @@ import (
 	yamlv3 "gopkg.in/yaml.v3"
 
 	test "github.com/walteh/schema2go/tests/data/extraImports/gopkgYAMLv3"
+	testObjectProperties "github.com/walteh/schema2go/tests/data/validation/objectProperties"
+	testPatternProperties "github.com/walteh/schema2go/tests/data/validation/patternProperties"
 )
 
 func TestYamlV3Unmarshal(t *testing.T) {
@@ -68,3 +70,37 @@ func TestYamlV3UnmarshalInvalidEnum(t *testing.T) {
 		t.Error("Expected unmarshal error to contain enum values")
 	}
 }
+
+func TestYamlV3UnmarshalObjectProperties(t *testing.T) {
+	t.Parallel()
+
+	var conf testObjectProperties.ObjectProperties
+
+	if err := yamlv3.Unmarshal([]byte("labels:\n  app: web\n"), &conf); err != nil {
+		t.Fatal(err)
+	}
+
+	err := yamlv3.Unmarshal([]byte("labels:\n  App: web\n"), &conf)
+	if err == nil || !strings.Contains(err.Error(), `property name "App" in ObjectPropertiesLabels`) {
+		t.Errorf("Expected an error for property name App, got %v", err)
+	}
+}
+
+func TestYamlV3UnmarshalPatternProperties(t *testing.T) {
+	t.Parallel()
+
+	var conf testPatternProperties.PatternProperties
+
+	if err := yamlv3.Unmarshal([]byte("S_title: hello\nN_count: 3\n"), &conf); err != nil {
+		t.Fatal(err)
+	}
+
+	if conf.SProperties["S_title"] != "hello" || conf.NProperties["N_count"] != 3 {
+		t.Errorf("Expected the pattern properties to be decoded, got %+v", conf)
+	}
+
+	err := yamlv3.Unmarshal([]byte("title: hello\n"), &conf)
+	if err == nil || !strings.Contains(err.Error(), `property "title" in PatternProperties: not allowed`) {
+		t.Errorf("Expected an error for property title, got %v", err)
+	}
+}
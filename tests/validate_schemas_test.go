@@ -0,0 +1,110 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/walteh/schema2go/pkg/generator"
+	"github.com/walteh/schema2go/pkg/schemas"
+)
+
+func TestValidateSchemasBeforeGenerating(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "count": {"type": "interger"}
+  },
+  "requried": ["count"]
+}`)
+
+	cfg := basicConfig
+	cfg.ValidateSchemas = true
+
+	g, err := generator.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = g.DoBytes(context.Background(), "memory/typos.json", data)
+	if !errors.Is(err, schemas.ErrInvalidSchema) {
+		t.Fatalf("Expected a meta-schema violation, got %v", err)
+	}
+
+	for _, want := range []string{
+		`memory/typos.json:5:15 (#/properties/count/type): must be one of`,
+		`did you mean "integer"?`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got %v", want, err)
+		}
+	}
+
+	if strings.Contains(err.Error(), "requried") {
+		t.Errorf("Expected misspelled keywords not to be violations, got %v", err)
+	}
+
+	if sources := g.Sources(); len(sources) != 0 {
+		t.Errorf("Expected no generated code, got %d file(s)", len(sources))
+	}
+}
+
+func TestValidateSchemasWarnsAboutMisspelledKeywords(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "count": {"type": "integer"}
+  },
+  "requried": ["count"]
+}`)
+
+	var warnings []string
+
+	cfg := basicConfig
+	cfg.ValidateSchemas = true
+	cfg.Warner = func(message string) {
+		warnings = append(warnings, message)
+	}
+
+	g, err := generator.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.DoBytes(context.Background(), "memory/typos.json", data); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `memory/typos.json:7:3 (#/requried): unknown keyword "requried"; did you mean "required"?`
+	if !slices.Contains(warnings, want) {
+		t.Errorf("Expected warning %q, got %q", want, warnings)
+	}
+
+	if sources := g.Sources(); len(sources) == 0 {
+		t.Error("Expected generated code")
+	}
+}
+
+func TestValidateSchemasAcceptsValidSchemas(t *testing.T) {
+	t.Parallel()
+
+	cfg := basicConfig
+	cfg.ValidateSchemas = true
+
+	g, err := generator.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.DoFile("./data/core/refExternalFile/refExternalFile.json"); err != nil {
+		t.Fatal(err)
+	}
+}
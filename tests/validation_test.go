@@ -5,14 +5,21 @@ import (
 	"errors"
 	"testing"
 
+	testArrayItems "github.com/walteh/schema2go/tests/data/validation/arrayItems"
+	testConst "github.com/walteh/schema2go/tests/data/validation/const"
+	testDependencies "github.com/walteh/schema2go/tests/data/validation/dependencies"
 	testExclusiveMaximum "github.com/walteh/schema2go/tests/data/validation/exclusiveMaximum"
 	testExclusiveMinimum "github.com/walteh/schema2go/tests/data/validation/exclusiveMinimum"
+	testIfThenElse "github.com/walteh/schema2go/tests/data/validation/ifThenElse"
 	testMaxLength "github.com/walteh/schema2go/tests/data/validation/maxLength"
 	testMaximum "github.com/walteh/schema2go/tests/data/validation/maximum"
 	testMinLength "github.com/walteh/schema2go/tests/data/validation/minLength"
 	testMinimum "github.com/walteh/schema2go/tests/data/validation/minimum"
 	testMultipleOf "github.com/walteh/schema2go/tests/data/validation/multipleOf"
+	testNot "github.com/walteh/schema2go/tests/data/validation/not"
+	testObjectProperties "github.com/walteh/schema2go/tests/data/validation/objectProperties"
 	testPattern "github.com/walteh/schema2go/tests/data/validation/pattern"
+	testPatternProperties "github.com/walteh/schema2go/tests/data/validation/patternProperties"
 	testPrimitiveDefs "github.com/walteh/schema2go/tests/data/validation/primitive_defs"
 	testRequiredFields "github.com/walteh/schema2go/tests/data/validation/requiredFields"
 	"github.com/walteh/schema2go/tests/helpers"
@@ -430,3 +437,473 @@ func TestExclusiveMinimum(t *testing.T) {
 		})
 	}
 }
+
+func TestConst(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		data    string
+		wantErr error
+	}{
+		{
+			desc: "no violations",
+			data: `{"kind": "widget", "count": 3, "ratio": 1.5, "enabled": true, "tags": ["a", "b"], "version": 2,
+				"point": {"y": 2, "x": 1}}`,
+		},
+		{
+			desc:    "kind has another value",
+			data:    `{"kind": "gadget", "count": 3}`,
+			wantErr: errors.New(`field kind: must be "widget"`),
+		},
+		{
+			desc:    "enabled has another value",
+			data:    `{"kind": "widget", "count": 3, "enabled": false}`,
+			wantErr: errors.New("field enabled: must be true"),
+		},
+		{
+			desc:    "tags has another value",
+			data:    `{"kind": "widget", "count": 3, "tags": ["b", "a"]}`,
+			wantErr: errors.New(`field tags: must be ["a","b"]`),
+		},
+		{
+			desc:    "version has another value",
+			data:    `{"kind": "widget", "count": 3, "version": 1}`,
+			wantErr: errors.New("field Version: must be 2"),
+		},
+		{
+			desc:    "point has another value",
+			data:    `{"kind": "widget", "count": 3, "point": {"x": 1, "y": 3}}`,
+			wantErr: errors.New(`field Point: must be {"x":1,"y":2}`),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			model := testConst.Const{}
+
+			err := json.Unmarshal([]byte(tC.data), &model)
+
+			helpers.CheckError(t, tC.wantErr, err)
+		})
+	}
+}
+
+func TestConstMarshal(t *testing.T) {
+	t.Parallel()
+
+	b, err := json.Marshal(testConst.Const{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"count":3,"enabled":true,"kind":"widget","ratio":1.5,"untyped":"inferred"}`
+	if string(b) != want {
+		t.Errorf("Expected %s, got %s", want, b)
+	}
+
+	var model testConst.Const
+	if err := json.Unmarshal(b, &model); err != nil {
+		t.Errorf("Expected marshaled value to unmarshal, got %v", err)
+	}
+}
+
+func TestIfThenElse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		data    string
+		wantErr error
+	}{
+		{
+			desc: "then holds",
+			data: `{"kind": "s3", "bucket": "logs", "region": "eu-west-1"}`,
+		},
+		{
+			desc:    "then requires bucket",
+			data:    `{"kind": "s3"}`,
+			wantErr: errors.New(`field bucket: required when kind is "s3"`),
+		},
+		{
+			desc:    "then constrains bucket",
+			data:    `{"kind": "s3", "bucket": "a"}`,
+			wantErr: errors.New(`field bucket: length must be >= 3 when kind is "s3"`),
+		},
+		{
+			desc:    "then constrains region",
+			data:    `{"kind": "s3", "bucket": "logs", "region": "mars"}`,
+			wantErr: errors.New(`field region: must match ^[a-z]+-[a-z]+-[0-9]$ when kind is "s3"`),
+		},
+		{
+			desc: "else holds",
+			data: `{"kind": "local", "region": "mars"}`,
+		},
+		{
+			desc:    "else constrains bucket",
+			data:    `{"kind": "gcs", "bucket": 1}`,
+			wantErr: errors.New(`field bucket: must be a string unless kind is "s3"`),
+		},
+		{
+			desc: "mirror conditions hold",
+			data: `{"kind": "local", "mirror": {"url": "https://example.com", "priority": 1}}`,
+		},
+		{
+			desc:    "mirror else requires insecure",
+			data:    `{"kind": "local", "mirror": {"url": "http://example.com"}}`,
+			wantErr: errors.New("field insecure: required unless url matches ^https://"),
+		},
+		{
+			desc:    "mirror then requires disabledReason",
+			data:    `{"kind": "local", "mirror": {"url": "https://example.com", "priority": 0}}`,
+			wantErr: errors.New("field disabledReason: required when priority is one of [0]"),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			model := testIfThenElse.IfThenElse{}
+
+			err := json.Unmarshal([]byte(tC.data), &model)
+
+			helpers.CheckError(t, tC.wantErr, err)
+		})
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		data    string
+		wantErr error
+	}{
+		{
+			desc: "no violations",
+			data: `{"name": "a", "creditCard": "1", "billingAddress": "b", "proxy": "p", "proxyPort": 80}`,
+		},
+		{
+			desc:    "creditCard requires billingAddress",
+			data:    `{"name": "a", "creditCard": "1"}`,
+			wantErr: errors.New("field billingAddress: required when creditCard is set"),
+		},
+		{
+			desc:    "billingAddress requires name",
+			data:    `{"billingAddress": "b"}`,
+			wantErr: errors.New("field name: required when billingAddress is set"),
+		},
+		{
+			desc:    "proxy requires proxyPort",
+			data:    `{"proxy": "p"}`,
+			wantErr: errors.New("field proxyPort: required when proxy is set"),
+		},
+		{
+			desc:    "proxy constrains proxyScheme",
+			data:    `{"proxy": "p", "proxyPort": 80, "proxyScheme": "ftp"}`,
+			wantErr: errors.New(`field proxyScheme: must be one of ["http","https"] when proxy is set`),
+		},
+		{
+			desc: "proxyPort alone",
+			data: `{"proxyPort": 80}`,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			model := testDependencies.Dependencies{}
+
+			err := json.Unmarshal([]byte(tC.data), &model)
+
+			helpers.CheckError(t, tC.wantErr, err)
+		})
+	}
+}
+
+func TestObjectProperties(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		data    string
+		wantErr error
+	}{
+		{
+			desc: "no violations",
+			data: `{"labels": {"app": "web"}, "limits": {"cpu": 2}, "extra": {"id": "a", "zone": "b"}}`,
+		},
+		{
+			desc:    "too few properties",
+			data:    `{}`,
+			wantErr: errors.New("ObjectProperties: number of properties must be >= 1"),
+		},
+		{
+			desc:    "too many properties",
+			data:    `{"name": "a", "labels": {"a": "b"}, "limits": {}, "extra": {}}`,
+			wantErr: errors.New("ObjectProperties: number of properties must be <= 3"),
+		},
+		{
+			desc:    "empty map",
+			data:    `{"labels": {}}`,
+			wantErr: errors.New("ObjectPropertiesLabels: number of properties must be >= 1"),
+		},
+		{
+			desc:    "map key does not match pattern",
+			data:    `{"labels": {"App": "web"}}`,
+			wantErr: errors.New(`property name "App" in ObjectPropertiesLabels: must match ^[a-z]+$`),
+		},
+		{
+			desc:    "map key too long",
+			data:    `{"labels": {"application": "web"}}`,
+			wantErr: errors.New(`property name "application" in ObjectPropertiesLabels: length must be <= 8`),
+		},
+		{
+			desc:    "map key not allowed",
+			data:    `{"limits": {"disk": 1}}`,
+			wantErr: errors.New(`property name "disk" in ObjectPropertiesLimits: must be one of ["cpu" "memory"]`),
+		},
+		{
+			desc:    "struct with too many additional properties",
+			data:    `{"extra": {"id": "a", "zone": "b", "rack": "c"}}`,
+			wantErr: errors.New("ObjectPropertiesExtra: number of properties must be <= 2"),
+		},
+		{
+			desc:    "struct with short property name",
+			data:    `{"extra": {"z": "b"}}`,
+			wantErr: errors.New(`property name "z" in ObjectPropertiesExtra: length must be >= 2`),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			model := testObjectProperties.ObjectProperties{}
+
+			err := json.Unmarshal([]byte(tC.data), &model)
+
+			helpers.CheckError(t, tC.wantErr, err)
+		})
+	}
+}
+
+func TestArrayItems(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		data    string
+		wantErr error
+	}{
+		{
+			desc: "no violations",
+			data: `{"tags": ["primary", "b"], "points": [{"x": 1}, {"x": 1, "y": 2}], "ports": [80, 8080]}`,
+		},
+		{
+			desc:    "duplicate tags",
+			data:    `{"tags": ["primary", "b", "primary"]}`,
+			wantErr: errors.New("field tags: items 0 and 2 must be unique"),
+		},
+		{
+			desc:    "duplicate points with differently written numbers",
+			data:    `{"points": [{"x": 1, "y": 2}, {"y": 2.0, "x": 1}]}`,
+			wantErr: errors.New("field points: items 0 and 1 must be unique"),
+		},
+		{
+			desc:    "tags without primary",
+			data:    `{"tags": ["a", "b"]}`,
+			wantErr: errors.New(`field tags: must contain >= 1 items where item is "primary", found 0 at indices []`),
+		},
+		{
+			desc:    "too many well-known ports",
+			data:    `{"ports": [80, 22, 443, 80]}`,
+			wantErr: errors.New("field ports: must contain <= 2 items where item is one of [80,443], found 3 at indices [0 2 3]"),
+		},
+		{
+			desc: "referenced array type",
+			data: `{"ids": [1, 2]}`,
+		},
+		{
+			desc:    "duplicate items of a referenced array type",
+			data:    `{"ids": [1, 2, 2]}`,
+			wantErr: errors.New("field Ids: items 1 and 2 must be unique"),
+		},
+		{
+			desc:    "referenced array type without a contained item",
+			data:    `{"ids": [2, 3]}`,
+			wantErr: errors.New("field Ids: must contain >= 1 items where item is 1, found 0 at indices []"),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			model := testArrayItems.ArrayItems{}
+
+			err := json.Unmarshal([]byte(tC.data), &model)
+
+			helpers.CheckError(t, tC.wantErr, err)
+		})
+	}
+}
+
+func TestNot(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		data    string
+		wantErr error
+	}{
+		{
+			desc: "no violations",
+			data: `{"role": "user", "value": 1, "username": "a", "color": "red"}`,
+		},
+		{
+			desc:    "forbidden enum value",
+			data:    `{"role": "admin"}`,
+			wantErr: errors.New(`field role: must not match role is one of ["root","admin"]`),
+		},
+		{
+			desc:    "forbidden type",
+			data:    `{"value": null}`,
+			wantErr: errors.New("field value: must not match value is null"),
+		},
+		{
+			desc:    "forbidden combination of properties",
+			data:    `{"username": "a", "token": "b"}`,
+			wantErr: errors.New("Not: must not match username is set and token is set"),
+		},
+		{
+			desc:    "forbidden value of a referenced type",
+			data:    `{"color": "transparent"}`,
+			wantErr: errors.New(`Color: must not match value is "transparent"`),
+		},
+		{
+			desc:    "forbidden value of a referenced array type",
+			data:    `{"codes": [0]}`,
+			wantErr: errors.New(`Codes: must not match value is [0]`),
+		},
+		{
+			desc:    "forbidden value of a referenced map type",
+			data:    `{"limits": {"max": 0}}`,
+			wantErr: errors.New(`Limits: must not match value is {"max":0}`),
+		},
+		{
+			desc: "allowed values of referenced array and map types",
+			data: `{"codes": [0, 1], "limits": {"max": 1}}`,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			model := testNot.Not{}
+
+			err := json.Unmarshal([]byte(tC.data), &model)
+
+			helpers.CheckError(t, tC.wantErr, err)
+		})
+	}
+}
+
+func TestPatternProperties(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		data    string
+		wantErr error
+	}{
+		{
+			desc: "no violations",
+			data: `{"name": "a", "S_title": "hello", "N_count": 3, "labels": {"app": "web"}}`,
+		},
+		{
+			desc:    "value does not match its pattern schema",
+			data:    `{"S_title": 1}`,
+			wantErr: errors.New(`property "S_title" in PatternProperties: must be a string`),
+		},
+		{
+			desc:    "value violates a constraint of its pattern schema",
+			data:    `{"S_title": "a"}`,
+			wantErr: errors.New(`property "S_title" in PatternProperties: length must be >= 2`),
+		},
+		{
+			desc:    "property matches no pattern",
+			data:    `{"title": "hello"}`,
+			wantErr: errors.New(`property "title" in PatternProperties: not allowed`),
+		},
+		{
+			desc:    "map key matches no pattern",
+			data:    `{"labels": {"App": "web"}}`,
+			wantErr: errors.New(`property "App" in Labels: not allowed`),
+		},
+		{
+			desc:    "pattern value of a declared type is validated",
+			data:    `{"extensions": {"x-cache": {}}}`,
+			wantErr: errors.New("field enabled in PatternPropertiesExtensionsPatternPropertiesValue: required"),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			model := testPatternProperties.PatternProperties{}
+
+			err := json.Unmarshal([]byte(tC.data), &model)
+
+			helpers.CheckError(t, tC.wantErr, err)
+		})
+	}
+}
+
+func TestPatternPropertiesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	data := `{"name": "a", "S_title": "hello", "N_count": 3, "extensions": {"x-cache": {"enabled": true}, "owner": "me"}}`
+
+	var model testPatternProperties.PatternProperties
+	if err := json.Unmarshal([]byte(data), &model); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := model.SProperties["S_title"]; got != "hello" {
+		t.Errorf("Expected S_title to be hello, got %q", got)
+	}
+
+	if got := model.NProperties["N_count"]; got != 3 {
+		t.Errorf("Expected N_count to be 3, got %v", got)
+	}
+
+	if got := model.Extensions.PatternProperties["x-cache"]; !got.Enabled {
+		t.Errorf("Expected x-cache to be enabled, got %+v", got)
+	}
+
+	if got := model.Extensions.AdditionalProperties; len(got) != 1 || got["owner"] != "me" {
+		t.Errorf("Expected only owner in the additional properties, got %v", got)
+	}
+
+	b, err := json.Marshal(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var roundTrip testPatternProperties.PatternProperties
+	if err := json.Unmarshal(b, &roundTrip); err != nil {
+		t.Fatalf("Expected marshaled value %s to unmarshal, got %v", b, err)
+	}
+
+	if got := roundTrip.SProperties["S_title"]; got != "hello" {
+		t.Errorf("Expected S_title to survive marshaling, got %s", b)
+	}
+}
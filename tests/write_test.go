@@ -0,0 +1,142 @@
+package tests_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/walteh/schema2go/pkg/codegen"
+	"github.com/walteh/schema2go/pkg/generator"
+)
+
+func TestWriteSources(t *testing.T) {
+	t.Parallel()
+
+	cfg := basicConfig
+	cfg.SchemaMappings = []generator.SchemaMapping{
+		{
+			SchemaID:    "https://example.com/schema",
+			PackageName: "github.com/walteh/schema2go/tests/helpers/schema",
+			OutputName:  "schema.go",
+		},
+		{
+			SchemaID:    "https://example.com/other",
+			PackageName: "github.com/walteh/schema2go/tests/data/crossPackage/other",
+			OutputName:  "../other/other.go",
+		},
+	}
+
+	g, err := generator.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.DoFile("./data/crossPackage/schema/schema.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "schema")
+
+	written, err := g.WriteSources(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{filepath.Join(dir, "../other/other.go"), filepath.Join(dir, "schema.go")}
+	if !reflect.DeepEqual(written, want) {
+		t.Fatalf("Expected %v to be written, got %v", want, written)
+	}
+
+	for _, fileName := range written {
+		content, err := os.ReadFile(fileName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.HasPrefix(string(content), "// "+codegen.GeneratedComment+"\n") {
+			t.Errorf("Expected %s to start with the generated header, got:\n%s", fileName, content)
+		}
+	}
+
+	written, err = g.WriteSources(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(written) != 0 {
+		t.Errorf("Expected unchanged files to be skipped, got %v", written)
+	}
+}
+
+func TestWriteSourcesAddsHeader(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	if _, err := generator.WriteSources(dir, map[string][]byte{"a.go": []byte("package a\n")}); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "a.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "// " + codegen.GeneratedComment + "\n\npackage a\n"; string(content) != want {
+		t.Errorf("Expected %q, got %q", want, content)
+	}
+}
+
+func TestRemoveStaleSources(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	header := "// " + codegen.GeneratedComment + "\n\n"
+
+	files := map[string]string{
+		"current.go":         header + "package a\n",
+		"old.go":             header + "package a\n",
+		"handwritten.go":     "package a\n",
+		"notes.txt":          header,
+		"sub/current.go":     header + "package sub\n",
+		"sub/old.go":         header + "package sub\n",
+		"unrelated/gen.go":   header + "package unrelated\n",
+		"sub/handwritten.go": "// Package sub is not generated.\npackage sub\n",
+	}
+
+	for name, content := range files {
+		fileName := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(fileName), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(fileName, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The generated files of dir itself are left alone, since no source is
+	// written there.
+	removed, err := generator.RemoveStaleSources(dir, map[string][]byte{
+		"sub/current.go": nil,
+		"-":              nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{filepath.Join(dir, "sub/old.go")}
+	if !reflect.DeepEqual(removed, want) {
+		t.Fatalf("Expected %v to be removed, got %v", want, removed)
+	}
+
+	for name := range files {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if exists, stale := err == nil, name == "sub/old.go"; exists == stale {
+			t.Errorf("Expected %s to exist: %t", name, !stale)
+		}
+	}
+}